@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cleogithub/golem/coredomaindefinition"
+	"github.com/cleogithub/golem/goGeneration/domain/filesystem"
+	"github.com/cleogithub/golem/goGeneration/domain/usecase"
+)
+
+// Exit codes, usable in CI
+const (
+	EXIT_OK      = 0
+	EXIT_CHANGES = 1 // diff found changes
+	EXIT_USAGE   = 2 // bad command line
+	EXIT_ERROR   = 3 // invalid definition or generation failure
+)
+
+const USAGE = `Usage: golem <command> [flags] <definition file>
+
+Flags may also follow the definition file.
+
+Commands:
+  generate  generate the domain in the output folder
+  validate  run the builders without writing anything
+  diff      show the files a generation would add, modify or delete, with unified diffs
+
+Exit codes:
+  0  success, nothing to change
+  1  diff found changes
+  2  bad command line
+  3  invalid definition or generation failure
+`
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) < 1 {
+		fmt.Fprint(stderr, USAGE)
+		return EXIT_USAGE
+	}
+
+	command := args[0]
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, USAGE+"\nFlags:\n")
+		flags.PrintDefaults()
+	}
+	output := flags.String("o", ".", "output folder of the generation")
+	nameOnly := flags.Bool("name-only", false, "diff: only list changed files")
+	tidy := flags.Bool("tidy", false, "generate: run go mod tidy on the generated module, needs network access")
+
+	switch command {
+	case "generate", "validate", "diff":
+	case "help", "-h", "--help":
+		fmt.Fprint(stdout, USAGE)
+		return EXIT_OK
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s", command, USAGE)
+		return EXIT_USAGE
+	}
+
+	positionals, err := parseInterspersed(flags, args[1:])
+	if err != nil {
+		return EXIT_USAGE
+	}
+	if len(positionals) != 1 {
+		flags.Usage()
+		return EXIT_USAGE
+	}
+
+	definition, err := coredomaindefinition.LoadDomainFile(positionals[0])
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return EXIT_ERROR
+	}
+
+	generation := &usecase.GenerationUsecaseImpl{
+		Tidy: *tidy,
+	}
+	switch command {
+	case "generate":
+		err = generation.GenerateDomainUsecase(ctx, *definition, *output)
+	case "validate":
+		err = generation.ValidateDomainUsecase(ctx, *definition)
+	case "diff":
+		var changes []*filesystem.FileChange
+		changes, err = generation.DryRunDomainUsecase(ctx, *definition, *output)
+		if err == nil {
+			for _, change := range changes {
+				fmt.Fprintf(stdout, "%s %s\n", change.Type, change.Path)
+				if !*nameOnly {
+					fmt.Fprint(stdout, change.Diff)
+				}
+			}
+			if len(changes) > 0 {
+				return EXIT_CHANGES
+			}
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return EXIT_ERROR
+	}
+
+	return EXIT_OK
+}
+
+// parseInterspersed parses flags placed before or after the positional arguments, e.g. golem generate domain.yaml -o out.
+// Arguments after "--" are positional.
+func parseInterspersed(flags *flag.FlagSet, args []string) ([]string, error) {
+	positionals := []string{}
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		if flags.NArg() == 0 {
+			return positionals, nil
+		}
+		if consumed := len(args) - flags.NArg(); consumed > 0 && args[consumed-1] == "--" {
+			return append(positionals, flags.Args()...), nil
+		}
+		positionals = append(positionals, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+}
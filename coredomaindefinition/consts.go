@@ -8,6 +8,12 @@ const (
 	ADAPTER_FOLDER    = "adapter"
 	CONTROLLER_FOLDER = "controller"
 	REPOSITORY_FOLDER = "repository"
+	CONSTS_FOLDER     = "consts"
+	SDK_FOLDER        = "sdk"
+	CLIENT_FOLDER     = "client"
+	JS_CLIENT_FOLDER  = "JS"
+	GORM_FOLDER       = "gormadapter"
+	HTTP_FOLDER       = "httpadapter"
 
 	DOMAIN_PATH  = ""
 	USECASE_PATH = DOMAIN_PATH + "/" + DOMAIN_FOLDER
@@ -18,4 +24,12 @@ const (
 	REPOSITORY_PATH = PORT_PATH + "/" + PORT_FOLDER
 
 	ADAPTER_PATH = ""
+	SDK_PATH     = ""
+)
+
+const (
+	// ROLES_FOLDER is the folder of the shared platform roles package, next to the domain folders
+	ROLES_FOLDER = "roles"
+	// ROLES_ALL_NAME is the name of the generated list of every platform role
+	ROLES_ALL_NAME = "All"
 )
@@ -0,0 +1,17 @@
+package coredomaindefinition
+
+// DefaultGenerator is a field default computed when the model is created
+type DefaultGenerator string
+
+const (
+	// DefaultGeneratorNow is the current datetime, date or time of day
+	DefaultGeneratorNow DefaultGenerator = "now"
+	// DefaultGeneratorUUID is a random uuid, for string fields
+	DefaultGeneratorUUID DefaultGenerator = "uuid"
+)
+
+// DEFAULT_GENERATORS are the generators usable as field defaults, a string default with one of their names is the generator
+var DEFAULT_GENERATORS = []DefaultGenerator{
+	DefaultGeneratorNow,
+	DefaultGeneratorUUID,
+}
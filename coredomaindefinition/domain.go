@@ -4,6 +4,9 @@ type Domain struct {
 	Name          string
 	Configuration *DomainConfiguration
 	Models        []*Model
+	Enums         []*Enum
+	ValueObjects  []*ValueObject
+	ExternalTypes []*ExternalType
 	Relations     []*Relation
 	Repositories  []*Repository
 	Usecases      []*Usecase
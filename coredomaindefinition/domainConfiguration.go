@@ -21,6 +21,18 @@ type DomainConfiguration struct {
 	ControllerFolder string
 	// RepositoryFolder is the folder path of repository layer.
 	RepositoryFolder string
+	// ConstsFolder is the folder path of consts package, in domain folder.
+	ConstsFolder string
+	// SdkFolder is the folder path of sdk layer.
+	SdkFolder string
+	// ClientFolder is the folder path of go client, in sdk folder.
+	ClientFolder string
+	// JavascriptClientFolder is the folder path of javascript client, in sdk folder.
+	JavascriptClientFolder string
+	// GormFolder is the folder path of gorm adapter, in adapter repository folder.
+	GormFolder string
+	// HttpFolder is the folder path of http adapter, in adapter controller folder.
+	HttpFolder string
 
 	// DomainPath is the path of domain layer.
 	DomainPath string
@@ -36,8 +48,26 @@ type DomainConfiguration struct {
 	RepositoryPath string
 	// AdapterPath is the path of adapter layer.
 	AdapterPath string
+	// SdkPath is the path of sdk layer.
+	SdkPath string
+
+	// TemplatesPath is the folder of the templates overriding the built-in ones (route.tmpl, httpClientRoute.tmpl).
+	TemplatesPath string
+
+	// IDStrategy is the primary key strategy of the models which do not set one, uuid when empty.
+	IDStrategy IDStrategy
+	// Dialect is the database of the gorm adapter. Arrays of primitives are native arrays with postgres, JSON columns otherwise.
+	Dialect Dialect
 }
 
+type Dialect string
+
+const (
+	DialectPostgres Dialect = "postgres"
+	DialectMySQL    Dialect = "mysql"
+	DialectSQLite   Dialect = "sqlite"
+)
+
 func (domainConfiguration *DomainConfiguration) GetDomainFolder() string {
 	if domainConfiguration.DomainFolder == "" {
 		return DOMAIN_FOLDER
@@ -95,36 +125,41 @@ func (domainConfiguration *DomainConfiguration) GetDomainPath() string {
 }
 
 func (domainConfiguration *DomainConfiguration) GetUsecasePath() string {
+	// default follows the parent layer configuration
 	if domainConfiguration.UsecasePath == "" {
-		return USECASE_PATH
+		return domainConfiguration.GetDomainPath() + "/" + domainConfiguration.GetDomainFolder()
 	}
 	return domainConfiguration.UsecasePath
 }
 
 func (domainConfiguration *DomainConfiguration) GetModelPath() string {
+	// default follows the parent layer configuration
 	if domainConfiguration.ModelPath == "" {
-		return MODEL_PATH
+		return domainConfiguration.GetDomainPath() + "/" + domainConfiguration.GetDomainFolder()
 	}
 	return domainConfiguration.ModelPath
 }
 
 func (domainConfiguration *DomainConfiguration) GetPortPath() string {
+	// default follows the parent layer configuration
 	if domainConfiguration.PortPath == "" {
-		return PORT_PATH
+		return domainConfiguration.GetDomainPath() + "/" + domainConfiguration.GetDomainFolder()
 	}
 	return domainConfiguration.PortPath
 }
 
 func (domainConfiguration *DomainConfiguration) GetControllerPath() string {
+	// default follows the parent layer configuration
 	if domainConfiguration.ControllerPath == "" {
-		return CONTROLLER_PATH
+		return domainConfiguration.GetPortPath() + "/" + domainConfiguration.GetPortFolder()
 	}
 	return domainConfiguration.ControllerPath
 }
 
 func (domainConfiguration *DomainConfiguration) GetRepositoryPath() string {
+	// default follows the parent layer configuration
 	if domainConfiguration.RepositoryPath == "" {
-		return REPOSITORY_PATH
+		return domainConfiguration.GetPortPath() + "/" + domainConfiguration.GetPortFolder()
 	}
 	return domainConfiguration.RepositoryPath
 }
@@ -135,3 +170,52 @@ func (domainConfiguration *DomainConfiguration) GetAdapterPath() string {
 	}
 	return domainConfiguration.AdapterPath
 }
+
+func (domainConfiguration *DomainConfiguration) GetConstsFolder() string {
+	if domainConfiguration.ConstsFolder == "" {
+		return CONSTS_FOLDER
+	}
+	return domainConfiguration.ConstsFolder
+}
+
+func (domainConfiguration *DomainConfiguration) GetSdkFolder() string {
+	if domainConfiguration.SdkFolder == "" {
+		return SDK_FOLDER
+	}
+	return domainConfiguration.SdkFolder
+}
+
+func (domainConfiguration *DomainConfiguration) GetClientFolder() string {
+	if domainConfiguration.ClientFolder == "" {
+		return CLIENT_FOLDER
+	}
+	return domainConfiguration.ClientFolder
+}
+
+func (domainConfiguration *DomainConfiguration) GetJavascriptClientFolder() string {
+	if domainConfiguration.JavascriptClientFolder == "" {
+		return JS_CLIENT_FOLDER
+	}
+	return domainConfiguration.JavascriptClientFolder
+}
+
+func (domainConfiguration *DomainConfiguration) GetGormFolder() string {
+	if domainConfiguration.GormFolder == "" {
+		return GORM_FOLDER
+	}
+	return domainConfiguration.GormFolder
+}
+
+func (domainConfiguration *DomainConfiguration) GetHttpFolder() string {
+	if domainConfiguration.HttpFolder == "" {
+		return HTTP_FOLDER
+	}
+	return domainConfiguration.HttpFolder
+}
+
+func (domainConfiguration *DomainConfiguration) GetSdkPath() string {
+	if domainConfiguration.SdkPath == "" {
+		return SDK_PATH
+	}
+	return domainConfiguration.SdkPath
+}
@@ -0,0 +1,11 @@
+package coredomaindefinition
+
+// Enum is a string type restricted to a list of values
+type Enum struct {
+	Name   string
+	Values []string
+}
+
+func (e Enum) GetType() string {
+	return e.Name
+}
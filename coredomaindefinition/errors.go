@@ -0,0 +1,318 @@
+package coredomaindefinition
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrUnknownType is returned when the type is unknown
+	ErrUnknownType = errors.New("unknown type ‘{type}’")
+
+	// ErrModelNotFound is returned when a referenced model is not defined in the domain
+	ErrModelNotFound = errors.New("model '{model}' not found")
+
+	// ErrRelationNotFound is returned when a referenced relation is not defined in the domain
+	ErrRelationNotFound = errors.New("relation between '{source}' and '{target}' not found")
+
+	// ErrAmbiguousRelation is returned when a reference without type matches several relations
+	ErrAmbiguousRelation = errors.New("several relations between '{source}' and '{target}', the relation type is required")
+
+	// ErrDuplicateDefinition is returned when a name is defined more than once
+	ErrDuplicateDefinition = errors.New("'{name}' is defined more than once")
+
+	// ErrRequired is returned when a required value is missing
+	ErrRequired = errors.New("{name} is required")
+
+	// ErrMissingRepository is returned when a CRUD is defined on a model without repository
+	ErrMissingRepository = errors.New("model '{model}' has no repository")
+
+	// ErrRelationNotInvolvingModel is returned when a CRUD manages a relation between two other models
+	ErrRelationNotInvolvingModel = errors.New("relation between '{source}' and '{target}' does not involve model '{model}'")
+
+	// ErrUnrelatedModel is returned when a model is used as related to another model without relation between them
+	ErrUnrelatedModel = errors.New("model '{model}' has no single relation to '{to}'")
+
+	// ErrModelNotActivable is returned when an action depending on active element is performed on a model which can not be inactive
+	ErrModelNotActivable = errors.New("model '{model}' and his dependency relations is not activable")
+
+	// ErrUnknownRelationType is returned when the relation type is unknown
+	ErrUnknownRelationType = errors.New("unknown relation type '{type}'")
+
+	// ErrUnknownValidationRule is returned when the validation rule is unknown
+	ErrUnknownValidationRule = errors.New("unknown validation rule '{rule}'")
+
+	// ErrValidationValueExpectedType is returned when the validation value is not of the expected type
+	ErrValidationValueExpectedType = errors.New("validation {rule} expected value of type {type}")
+
+	// ErrReservedName is returned when a name collides with a name generated by golem
+	ErrReservedName = errors.New("'{name}' collides with the generated '{generated}'")
+
+	// ErrIdentifierCollision is returned when two names generate the same identifier
+	ErrIdentifierCollision = errors.New("'{name}' and '{other}' both generate '{identifier}'")
+
+	// ErrUnknownRole is returned when a role is used but not declared by the platform
+	ErrUnknownRole = errors.New("role '{role}' is not declared by the platform")
+
+	// ErrInvalidEnumValue is returned when an enum value can not be used as a constant, a validation tag or in a SQL constraint
+	ErrInvalidEnumValue = errors.New("invalid enum value '{value}', only letters, digits, '_', '-' and '.' are allowed")
+
+	// ErrOptionalRequired is returned when a field is both optional and required
+	ErrOptionalRequired = errors.New("'{name}' can not be both optional and required")
+
+	// ErrUnsupportedValueObjectField is returned when a value object field type can not be stored in the columns of a model
+	ErrUnsupportedValueObjectField = errors.New("value object field of type '{type}' is not supported, only primitives and enums are")
+
+	// ErrUnexpectedPrecision is returned when precision or scale is set on a field which is not a decimal
+	ErrUnexpectedPrecision = errors.New("precision and scale are only allowed on decimal fields, not on '{type}'")
+
+	// ErrInvalidPrecision is returned when a decimal precision or scale does not fit in a numeric column
+	ErrInvalidPrecision = errors.New("invalid precision {precision} and scale {scale}, expected 0 < precision <= {max} and 0 <= scale <= precision")
+
+	// ErrUnsupportedMapType is returned when a map key is not a string, an int or an enum, or when a map value is a model or a file
+	ErrUnsupportedMapType = errors.New("map of '{type}' is not supported, keys are strings, ints or enums and values can not be models or files")
+
+	// ErrUnknownIDStrategy is returned when the primary key strategy of a model is unknown
+	ErrUnknownIDStrategy = errors.New("unknown id strategy '{strategy}', expected uuid, uuidv7, ulid or autoincrement")
+
+	// ErrUnknownDialect is returned when the database dialect of the configuration is unknown
+	ErrUnknownDialect = errors.New("unknown dialect '{dialect}', expected postgres, mysql or sqlite")
+
+	// ErrUnsupportedFileFormat is returned when the definition file extension is not supported
+	ErrUnsupportedFileFormat = errors.New("unsupported definition file format '{format}'")
+
+	// ErrUnexportedExternalType is returned when an external type can not be referenced from another package
+	ErrUnexportedExternalType = errors.New("external type '{name}' is not exported")
+
+	// ErrUnsupportedRepresentation is returned when an external type is represented by a type which is not a JSON scalar
+	ErrUnsupportedRepresentation = errors.New("representation '{type}' is not supported, only string, int, float and bool are")
+
+	// ErrIncompleteConverters is returned when an external type sets only one of its gorm converters
+	ErrIncompleteConverters = errors.New("external type '{name}' needs both toRepresentation and fromRepresentation")
+
+	// ErrInvalidDefault is returned when a default is neither a literal of the field type nor a generator of it
+	ErrInvalidDefault = errors.New("invalid default '{value}' for type '{type}'")
+
+	// ErrRequiredDefault is returned when a field with a default is also required, the empty value is rejected before the default applies
+	ErrRequiredDefault = errors.New("'{name}' can not be both required and defaulted")
+
+	// ErrAmbiguousDefault is returned when a default can not be told apart from an empty value, e.g. true on a non optional bool
+	ErrAmbiguousDefault = errors.New("default '{value}' of '{name}' replaces the zero value, the field must be optional")
+
+	// ErrInvalidPattern is returned when the value of a pattern validation is not a go regular expression
+	ErrInvalidPattern = errors.New("invalid pattern '{pattern}': {error}")
+
+	// ErrBacktickInTag is returned when a validation value written in a validate tag contains a backtick, the struct tags are raw strings
+	ErrBacktickInTag = errors.New("value '{value}' of validation {rule} can not contain a backtick")
+
+	// ErrFileValidation is returned when a validation rule of files is set on another type
+	ErrFileValidation = errors.New("validation {rule} only applies to files")
+
+	// ErrInvalidMIMEType is returned when a MIME type is not written type/subtype
+	ErrInvalidMIMEType = errors.New("invalid MIME type '{type}', expected type/subtype")
+
+	// ErrUnexpectedDefault is returned when a default is set elsewhere than on a model field
+	ErrUnexpectedDefault = errors.New("'{name}' can not have a default, only model fields can")
+
+	// ErrFieldNotFound is returned when a validation references a field which is not defined
+	ErrFieldNotFound = errors.New("field '{name}' not found")
+
+	// ErrFieldsValidationCount is returned when a validation on several fields has a wrong number of fields
+	ErrFieldsValidationCount = errors.New("validation {rule} expects {count} fields")
+
+	// ErrMismatchedFieldTypes is returned when two fields of different types are compared
+	ErrMismatchedFieldTypes = errors.New("'{field}' and '{other}' can not be compared, they are not of the same type")
+
+	// ErrUnsupportedFieldsValidation is returned when a validation on several fields does not apply to the type of a field
+	ErrUnsupportedFieldsValidation = errors.New("validation {rule} does not apply to '{name}' of type '{type}'")
+)
+
+func NewErrUnknownType(t string) error {
+	return errors.New(strings.Replace(ErrUnknownType.Error(), "{type}", t, 1))
+}
+
+func NewErrModelNotFound(model string) error {
+	str := strings.Replace(ErrModelNotFound.Error(), "{model}", model, 1)
+	return errors.New(str)
+}
+
+func NewErrRelationNotFound(source string, target string) error {
+	str := strings.Replace(ErrRelationNotFound.Error(), "{source}", source, 1)
+	return errors.New(strings.Replace(str, "{target}", target, 1))
+}
+
+func NewErrAmbiguousRelation(source string, target string) error {
+	str := strings.Replace(ErrAmbiguousRelation.Error(), "{source}", source, 1)
+	return errors.New(strings.Replace(str, "{target}", target, 1))
+}
+
+func NewErrUnsupportedFileFormat(format string) error {
+	str := strings.Replace(ErrUnsupportedFileFormat.Error(), "{format}", format, 1)
+	return errors.New(str)
+}
+
+func NewErrDuplicateDefinition(name string) error {
+	str := strings.Replace(ErrDuplicateDefinition.Error(), "{name}", name, 1)
+	return errors.New(str)
+}
+
+func NewErrRequired(name string) error {
+	str := strings.Replace(ErrRequired.Error(), "{name}", name, 1)
+	return errors.New(str)
+}
+
+func NewErrMissingRepository(model string) error {
+	str := strings.Replace(ErrMissingRepository.Error(), "{model}", model, 1)
+	return errors.New(str)
+}
+
+func NewErrRelationNotInvolvingModel(source string, target string, model string) error {
+	str := strings.Replace(ErrRelationNotInvolvingModel.Error(), "{source}", source, 1)
+	str = strings.Replace(str, "{target}", target, 1)
+	return errors.New(strings.Replace(str, "{model}", model, 1))
+}
+
+func NewErrUnrelatedModel(model string, to string) error {
+	str := strings.Replace(ErrUnrelatedModel.Error(), "{model}", model, 1)
+	return errors.New(strings.Replace(str, "{to}", to, 1))
+}
+
+func NewErrModelNotActivable(model string) error {
+	str := strings.Replace(ErrModelNotActivable.Error(), "{model}", model, 1)
+	return errors.New(str)
+}
+
+func NewErrUnknownRelationType(t string) error {
+	str := strings.Replace(ErrUnknownRelationType.Error(), "{type}", t, 1)
+	return errors.New(str)
+}
+
+func NewErrUnknownValidationRule(rule string) error {
+	str := strings.Replace(ErrUnknownValidationRule.Error(), "{rule}", rule, 1)
+	return errors.New(str)
+}
+
+func NewErrValidationValueExpectedType(rule string, t string) error {
+	str := strings.Replace(ErrValidationValueExpectedType.Error(), "{rule}", rule, 1)
+	return errors.New(strings.Replace(str, "{type}", t, 1))
+}
+
+func NewErrReservedName(name string, generated string) error {
+	str := strings.Replace(ErrReservedName.Error(), "{name}", name, 1)
+	return errors.New(strings.Replace(str, "{generated}", generated, 1))
+}
+
+func NewErrIdentifierCollision(name string, other string, identifier string) error {
+	str := strings.Replace(ErrIdentifierCollision.Error(), "{name}", name, 1)
+	str = strings.Replace(str, "{other}", other, 1)
+	return errors.New(strings.Replace(str, "{identifier}", identifier, 1))
+}
+
+func NewErrUnknownRole(role string) error {
+	str := strings.Replace(ErrUnknownRole.Error(), "{role}", role, 1)
+	return errors.New(str)
+}
+
+func NewErrInvalidEnumValue(value string) error {
+	return errors.New(strings.Replace(ErrInvalidEnumValue.Error(), "{value}", value, 1))
+}
+
+func NewErrOptionalRequired(name string) error {
+	return errors.New(strings.Replace(ErrOptionalRequired.Error(), "{name}", name, 1))
+}
+
+func NewErrUnsupportedValueObjectField(t string) error {
+	return errors.New(strings.Replace(ErrUnsupportedValueObjectField.Error(), "{type}", t, 1))
+}
+
+func NewErrUnexpectedPrecision(t string) error {
+	return errors.New(strings.Replace(ErrUnexpectedPrecision.Error(), "{type}", t, 1))
+}
+
+func NewErrInvalidPrecision(precision int, scale int) error {
+	str := strings.Replace(ErrInvalidPrecision.Error(), "{precision}", strconv.Itoa(precision), 1)
+	str = strings.Replace(str, "{scale}", strconv.Itoa(scale), 1)
+	return errors.New(strings.Replace(str, "{max}", strconv.Itoa(MAX_DECIMAL_PRECISION), 1))
+}
+
+func NewErrUnknownIDStrategy(strategy string) error {
+	return errors.New(strings.Replace(ErrUnknownIDStrategy.Error(), "{strategy}", strategy, 1))
+}
+
+func NewErrUnsupportedMapType(t string) error {
+	return errors.New(strings.Replace(ErrUnsupportedMapType.Error(), "{type}", t, 1))
+}
+
+func NewErrUnknownDialect(dialect string) error {
+	return errors.New(strings.Replace(ErrUnknownDialect.Error(), "{dialect}", dialect, 1))
+}
+
+func NewErrUnexportedExternalType(name string) error {
+	return errors.New(strings.Replace(ErrUnexportedExternalType.Error(), "{name}", name, 1))
+}
+
+func NewErrUnsupportedRepresentation(t string) error {
+	return errors.New(strings.Replace(ErrUnsupportedRepresentation.Error(), "{type}", t, 1))
+}
+
+func NewErrIncompleteConverters(name string) error {
+	return errors.New(strings.Replace(ErrIncompleteConverters.Error(), "{name}", name, 1))
+}
+
+func NewErrInvalidDefault(value interface{}, t string) error {
+	str := strings.Replace(ErrInvalidDefault.Error(), "{value}", fmt.Sprint(value), 1)
+	return errors.New(strings.Replace(str, "{type}", t, 1))
+}
+
+func NewErrRequiredDefault(name string) error {
+	return errors.New(strings.Replace(ErrRequiredDefault.Error(), "{name}", name, 1))
+}
+
+func NewErrAmbiguousDefault(value interface{}, name string) error {
+	str := strings.Replace(ErrAmbiguousDefault.Error(), "{value}", fmt.Sprint(value), 1)
+	return errors.New(strings.Replace(str, "{name}", name, 1))
+}
+
+func NewErrUnexpectedDefault(name string) error {
+	return errors.New(strings.Replace(ErrUnexpectedDefault.Error(), "{name}", name, 1))
+}
+
+func NewErrInvalidPattern(pattern string, err error) error {
+	str := strings.Replace(ErrInvalidPattern.Error(), "{pattern}", pattern, 1)
+	return errors.New(strings.Replace(str, "{error}", err.Error(), 1))
+}
+
+func NewErrBacktickInTag(rule string, value string) error {
+	str := strings.Replace(ErrBacktickInTag.Error(), "{rule}", rule, 1)
+	return errors.New(strings.Replace(str, "{value}", value, 1))
+}
+
+func NewErrFileValidation(rule string) error {
+	return errors.New(strings.Replace(ErrFileValidation.Error(), "{rule}", rule, 1))
+}
+
+func NewErrInvalidMIMEType(t string) error {
+	return errors.New(strings.Replace(ErrInvalidMIMEType.Error(), "{type}", t, 1))
+}
+
+func NewErrFieldNotFound(name string) error {
+	return errors.New(strings.Replace(ErrFieldNotFound.Error(), "{name}", name, 1))
+}
+
+func NewErrFieldsValidationCount(rule string, count string) error {
+	str := strings.Replace(ErrFieldsValidationCount.Error(), "{rule}", rule, 1)
+	return errors.New(strings.Replace(str, "{count}", count, 1))
+}
+
+func NewErrMismatchedFieldTypes(field string, other string) error {
+	str := strings.Replace(ErrMismatchedFieldTypes.Error(), "{field}", field, 1)
+	return errors.New(strings.Replace(str, "{other}", other, 1))
+}
+
+func NewErrUnsupportedFieldsValidation(rule string, name string, t string) error {
+	str := strings.Replace(ErrUnsupportedFieldsValidation.Error(), "{rule}", rule, 1)
+	str = strings.Replace(str, "{name}", name, 1)
+	return errors.New(strings.Replace(str, "{type}", t, 1))
+}
@@ -0,0 +1,49 @@
+package coredomaindefinition
+
+import "strings"
+
+// ExternalType is a go type owned by the user, e.g. {Package: "net/netip", Name: "Addr"}.
+// Its package name must be the last element of the import path, a major version suffix excluded.
+type ExternalType struct {
+	// import path of the package declaring the type
+	Package string
+	Name    string
+	// Representation is the primitive type of the JSON value, typing the javascript client. String when empty.
+	Representation PrimitiveType
+
+	// Optional converters of the gorm adapter, functions of Package: ToRepresentation(Name) Representation
+	// and FromRepresentation(Representation) Name, an int representation being an int64. When set the column stores the representation,
+	// otherwise the type is stored as is and must implement sql.Scanner and driver.Valuer.
+	ToRepresentation   string
+	FromRepresentation string
+}
+
+func (t ExternalType) GetType() string {
+	return t.Name
+}
+
+// GetRepresentation returns the primitive type of the JSON value and of the converted column
+func (t ExternalType) GetRepresentation() PrimitiveType {
+	if t.Representation == "" {
+		return PrimitiveTypeString
+	}
+	return t.Representation
+}
+
+// HasConverters reports whether the gorm adapter converts the type to its representation
+func (t ExternalType) HasConverters() bool {
+	return t.ToRepresentation != "" && t.FromRepresentation != ""
+}
+
+// GetPackageName returns the name of the package of the type, e.g. "yaml" for "gopkg.in/yaml.v3"
+func (t ExternalType) GetPackageName() string {
+	elements := strings.Split(t.Package, "/")
+	name := elements[len(elements)-1]
+	if len(elements) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = elements[len(elements)-2]
+	}
+	if i := strings.LastIndex(name, "."); i >= 0 && strings.HasPrefix(name[i+1:], "v") {
+		name = name[:i]
+	}
+	return strings.ReplaceAll(name, "-", "")
+}
@@ -4,4 +4,11 @@ type Field struct {
 	Name        string
 	Type        Type
 	Validations []*Validation
+	// Optional fields can be omitted, they are nullable in database
+	Optional bool
+	// Precision and Scale of decimal fields, DEFAULT_DECIMAL_PRECISION and DEFAULT_DECIMAL_SCALE when zero
+	Precision int
+	Scale     int
+	// Default is the value of the field when it is empty in a create request, a literal of its type or a DefaultGenerator
+	Default interface{}
 }
@@ -0,0 +1,39 @@
+package coredomaindefinition
+
+type FieldsValidationRule string
+
+const (
+	// comparisons of the first field to the second one, e.g. endDate gtField startDate
+	FieldsValidationRuleGTField  FieldsValidationRule = "gtField"
+	FieldsValidationRuleGTEField FieldsValidationRule = "gteField"
+	FieldsValidationRuleLTField  FieldsValidationRule = "ltField"
+	FieldsValidationRuleLTEField FieldsValidationRule = "lteField"
+	FieldsValidationRuleEQField  FieldsValidationRule = "eqField"
+	FieldsValidationRuleNEField  FieldsValidationRule = "neField"
+	// AnyRequired needs at least one of the fields to be set
+	FieldsValidationRuleAnyRequired FieldsValidationRule = "anyRequired"
+)
+
+// FieldsValidation is a rule on several fields of a model or args of a usecase.
+// The error is reported on the first field.
+type FieldsValidation struct {
+	Rule   FieldsValidationRule
+	Fields []string
+	// Message of the validation error, a default one is generated when empty
+	Message string
+}
+
+// IsComparison tells the rule compares two fields
+func (validation *FieldsValidation) IsComparison() bool {
+	switch validation.Rule {
+	case FieldsValidationRuleGTField, FieldsValidationRuleGTEField, FieldsValidationRuleLTField,
+		FieldsValidationRuleLTEField, FieldsValidationRuleEQField, FieldsValidationRuleNEField:
+		return true
+	}
+	return false
+}
+
+// IsOrdering tells the rule compares two fields by their order, not only by equality
+func (validation *FieldsValidation) IsOrdering() bool {
+	return validation.IsComparison() && validation.Rule != FieldsValidationRuleEQField && validation.Rule != FieldsValidationRuleNEField
+}
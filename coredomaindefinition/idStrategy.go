@@ -0,0 +1,26 @@
+package coredomaindefinition
+
+// IDStrategy is the way the primary key of a model is typed and generated
+type IDStrategy string
+
+const (
+	// IDStrategyUUID is a random uuid, the default strategy
+	IDStrategyUUID IDStrategy = "uuid"
+	// IDStrategyUUIDv7 is a time ordered uuid, keeping index insertions local
+	IDStrategyUUIDv7 IDStrategy = "uuidv7"
+	// IDStrategyULID is a time ordered, lexicographically sortable identifier
+	IDStrategyULID IDStrategy = "ulid"
+	// IDStrategyAutoIncrement is an int64 generated by the database
+	IDStrategyAutoIncrement IDStrategy = "autoincrement"
+)
+
+// GetIDStrategy returns the strategy of the model, the one of the domain configuration when not set
+func (domain *Domain) GetIDStrategy(m *Model) IDStrategy {
+	if m.IDStrategy != "" {
+		return m.IDStrategy
+	}
+	if domain.Configuration != nil && domain.Configuration.IDStrategy != "" {
+		return domain.Configuration.IDStrategy
+	}
+	return IDStrategyUUID
+}
@@ -0,0 +1,47 @@
+package coredomaindefinition
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cleogithub/golem-common/pkg/stringtool"
+)
+
+// GetIdentifierWords returns the words of the identifiers generated from a name, e.g. "in-progress" gives [in progress]
+func GetIdentifierWords(name string) []string {
+	return strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// GetRoleConstName returns the go constant name of a role, e.g. "super-admin" gives "SuperAdmin"
+func GetRoleConstName(role string) string {
+	name := ""
+	for _, word := range GetIdentifierWords(role) {
+		name += stringtool.UpperFirstLetter(word)
+	}
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "Role" + name
+	}
+	return name
+}
+
+// GetEnumValueConstName returns the go constant name of an enum value, e.g. "in-progress" of orderStatus gives "OrderStatusInProgress"
+func GetEnumValueConstName(enum *Enum, value string) string {
+	name := stringtool.UpperFirstLetter(enum.Name)
+	for _, word := range GetIdentifierWords(value) {
+		name += stringtool.UpperFirstLetter(word)
+	}
+	return name
+}
+
+// GetEnumValueJSKey returns the key of an enum value in the javascript frozen object, e.g. "in-progress" gives "IN_PROGRESS".
+// Keys which are not javascript identifiers, e.g. "2FA", are quoted.
+func GetEnumValueJSKey(value string) string {
+	key := strings.ToUpper(strings.Join(GetIdentifierWords(value), "_"))
+	if first, _ := utf8.DecodeRuneInString(key); unicode.IsDigit(first) {
+		return "'" + key + "'"
+	}
+	return key
+}
@@ -0,0 +1,32 @@
+package coredomaindefinition
+
+import (
+	"testing"
+)
+
+func TestEnumValueIdentifiers(t *testing.T) {
+	enum := &Enum{Name: "orderStatus"}
+
+	tests := []struct {
+		value     string
+		constName string
+		jsKey     string
+	}{
+		{value: "open", constName: "OrderStatusOpen", jsKey: "OPEN"},
+		{value: "in-progress", constName: "OrderStatusInProgress", jsKey: "IN_PROGRESS"},
+		{value: "in_progress", constName: "OrderStatusInProgress", jsKey: "IN_PROGRESS"},
+		{value: "v1.2", constName: "OrderStatusV12", jsKey: "V1_2"},
+		{value: "2fa", constName: "OrderStatus2fa", jsKey: "'2FA'"},
+		{value: "-1", constName: "OrderStatus1", jsKey: "'1'"},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			if constName := GetEnumValueConstName(enum, test.value); constName != test.constName {
+				t.Errorf("expected constant %s, got %s", test.constName, constName)
+			}
+			if jsKey := GetEnumValueJSKey(test.value); jsKey != test.jsKey {
+				t.Errorf("expected javascript key %s, got %s", test.jsKey, jsKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,601 @@
+package coredomaindefinition
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cleogithub/golem-common/pkg/merror"
+	"gopkg.in/yaml.v3"
+)
+
+// ARRAY_TYPE_PREFIX is the prefix used in definition files to declare an array type, e.g. "[]string"
+const ARRAY_TYPE_PREFIX = "[]"
+
+// MAP_TYPE_PREFIX is the prefix used in definition files to declare a map type, e.g. "map[string]int"
+const MAP_TYPE_PREFIX = "map["
+
+// DomainFile is the serializable form of a Domain. Models are referenced by name.
+type DomainFile struct {
+	Name          string                   `json:"name" yaml:"name"`
+	Configuration *DomainConfigurationFile `json:"configuration" yaml:"configuration"`
+	Controllers   ControllersFile          `json:"controllers" yaml:"controllers"`
+	Models        []*ModelFile             `json:"models" yaml:"models"`
+	Enums         []*EnumFile              `json:"enums" yaml:"enums"`
+	ValueObjects  []*ValueObjectFile       `json:"valueObjects" yaml:"valueObjects"`
+	ExternalTypes []*ExternalTypeFile      `json:"externalTypes" yaml:"externalTypes"`
+	Relations     []*RelationFile          `json:"relations" yaml:"relations"`
+	Repositories  []*RepositoryFile        `json:"repositories" yaml:"repositories"`
+	Usecases      []*UsecaseFile           `json:"usecases" yaml:"usecases"`
+	CRUDs         []*CRUDFile              `json:"cruds" yaml:"cruds"`
+}
+
+type DomainConfigurationFile struct {
+	DefaultOrderBy         string `json:"defaultOrderBy" yaml:"defaultOrderBy"`
+	Package                string `json:"package" yaml:"package"`
+	DomainFolder           string `json:"domainFolder" yaml:"domainFolder"`
+	ModelFolder            string `json:"modelFolder" yaml:"modelFolder"`
+	UsecaseFolder          string `json:"usecaseFolder" yaml:"usecaseFolder"`
+	PortFolder             string `json:"portFolder" yaml:"portFolder"`
+	AdapterFolder          string `json:"adapterFolder" yaml:"adapterFolder"`
+	ControllerFolder       string `json:"controllerFolder" yaml:"controllerFolder"`
+	RepositoryFolder       string `json:"repositoryFolder" yaml:"repositoryFolder"`
+	ConstsFolder           string `json:"constsFolder" yaml:"constsFolder"`
+	SdkFolder              string `json:"sdkFolder" yaml:"sdkFolder"`
+	ClientFolder           string `json:"clientFolder" yaml:"clientFolder"`
+	JavascriptClientFolder string `json:"javascriptClientFolder" yaml:"javascriptClientFolder"`
+	GormFolder             string `json:"gormFolder" yaml:"gormFolder"`
+	HttpFolder             string `json:"httpFolder" yaml:"httpFolder"`
+	DomainPath             string `json:"domainPath" yaml:"domainPath"`
+	UsecasePath            string `json:"usecasePath" yaml:"usecasePath"`
+	ModelPath              string `json:"modelPath" yaml:"modelPath"`
+	PortPath               string `json:"portPath" yaml:"portPath"`
+	ControllerPath         string `json:"controllerPath" yaml:"controllerPath"`
+	RepositoryPath         string `json:"repositoryPath" yaml:"repositoryPath"`
+	AdapterPath            string `json:"adapterPath" yaml:"adapterPath"`
+	SdkPath                string `json:"sdkPath" yaml:"sdkPath"`
+	TemplatesPath          string `json:"templatesPath" yaml:"templatesPath"`
+
+	IDStrategy IDStrategy `json:"idStrategy" yaml:"idStrategy"`
+	Dialect    Dialect    `json:"dialect" yaml:"dialect"`
+}
+
+type ControllersFile struct {
+	Http bool `json:"http" yaml:"http"`
+}
+
+type ModelFile struct {
+	Name      string       `json:"name" yaml:"name"`
+	Fields    []*FieldFile `json:"fields" yaml:"fields"`
+	Activable bool         `json:"activable" yaml:"activable"`
+	// Optionnal: true when omitted, as with NewModel
+	Archivable  *bool                   `json:"archivable" yaml:"archivable"`
+	IDStrategy  IDStrategy              `json:"idStrategy" yaml:"idStrategy"`
+	Validations []*FieldsValidationFile `json:"validations" yaml:"validations"`
+}
+
+type EnumFile struct {
+	Name   string   `json:"name" yaml:"name"`
+	Values []string `json:"values" yaml:"values"`
+}
+
+type ValueObjectFile struct {
+	Name   string       `json:"name" yaml:"name"`
+	Fields []*FieldFile `json:"fields" yaml:"fields"`
+}
+
+// ExternalTypeFile declares a go type of another package, referenced by its name in field types
+type ExternalTypeFile struct {
+	Package            string        `json:"package" yaml:"package"`
+	Name               string        `json:"name" yaml:"name"`
+	Representation     PrimitiveType `json:"representation" yaml:"representation"`
+	ToRepresentation   string        `json:"toRepresentation" yaml:"toRepresentation"`
+	FromRepresentation string        `json:"fromRepresentation" yaml:"fromRepresentation"`
+}
+
+type FieldFile struct {
+	Name        string            `json:"name" yaml:"name"`
+	Type        string            `json:"type" yaml:"type"`
+	Validations []*ValidationFile `json:"validations" yaml:"validations"`
+	Optional    bool              `json:"optional" yaml:"optional"`
+	Precision   int               `json:"precision" yaml:"precision"`
+	Scale       int               `json:"scale" yaml:"scale"`
+	// literal, or the name of a DefaultGenerator
+	Default interface{} `json:"default" yaml:"default"`
+}
+
+type ValidationFile struct {
+	Rule ValidationRule `json:"rule" yaml:"rule"`
+	// Model name for uniqueIn, list of strings for mimetypes and oneof, scalar otherwise
+	Value interface{} `json:"value" yaml:"value"`
+}
+
+// FieldsValidationFile is a rule on several fields, e.g. {rule: gtField, fields: [endDate, startDate]}
+type FieldsValidationFile struct {
+	Rule    FieldsValidationRule `json:"rule" yaml:"rule"`
+	Fields  []string             `json:"fields" yaml:"fields"`
+	Message string               `json:"message" yaml:"message"`
+}
+
+type RelationFile struct {
+	Source        string       `json:"source" yaml:"source"`
+	Target        string       `json:"target" yaml:"target"`
+	Type          RelationType `json:"type" yaml:"type"`
+	IgnoreReverse bool         `json:"ignoreReverse" yaml:"ignoreReverse"`
+}
+
+type RepositoryFile struct {
+	On             string                  `json:"on" yaml:"on"`
+	TableName      string                  `json:"tableName" yaml:"tableName"`
+	DefaultOrderBy string                  `json:"defaultOrderBy" yaml:"defaultOrderBy"`
+	Methods        []*RepositoryMethodFile `json:"methods" yaml:"methods"`
+}
+
+type RepositoryMethodFile struct {
+	Name      string       `json:"name" yaml:"name"`
+	Params    []*FieldFile `json:"params" yaml:"params"`
+	Results   []string     `json:"results" yaml:"results"`
+	Paginable bool         `json:"paginable" yaml:"paginable"`
+}
+
+type UsecaseFile struct {
+	Name        string                  `json:"name" yaml:"name"`
+	Args        []*FieldFile            `json:"args" yaml:"args"`
+	Results     []*FieldFile            `json:"results" yaml:"results"`
+	Roles       []string                `json:"roles" yaml:"roles"`
+	Validations []*FieldsValidationFile `json:"validations" yaml:"validations"`
+}
+
+type CRUDFile struct {
+	On            string              `json:"on" yaml:"on"`
+	Create        CRUDAction          `json:"create" yaml:"create"`
+	Get           CRUDAction          `json:"get" yaml:"get"`
+	GetActive     CRUDAction          `json:"getActive" yaml:"getActive"`
+	List          CRUDAction          `json:"list" yaml:"list"`
+	ListActive    CRUDAction          `json:"listActive" yaml:"listActive"`
+	Update        CRUDAction          `json:"update" yaml:"update"`
+	Delete        CRUDAction          `json:"delete" yaml:"delete"`
+	RelationCRUDs []*RelationCRUDFile `json:"relationCruds" yaml:"relationCruds"`
+}
+
+// RelationCRUDFile references a relation by its source and target model names
+type RelationCRUDFile struct {
+	Source string `json:"source" yaml:"source"`
+	Target string `json:"target" yaml:"target"`
+	// Type is only required when several relations link Source to Target
+	Type   RelationType `json:"type" yaml:"type"`
+	Roles  []string     `json:"roles" yaml:"roles"`
+	Add    CRUDAction   `json:"add" yaml:"add"`
+	Remove CRUDAction   `json:"remove" yaml:"remove"`
+	List   CRUDAction   `json:"list" yaml:"list"`
+}
+
+// LoadDomainFile reads a YAML (.yaml, .yml) or JSON (.json) definition file and resolves it into a Domain.
+func LoadDomainFile(path string) (*Domain, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, merror.Stack(err)
+	}
+
+	file := &DomainFile{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, file)
+	case ".json":
+		err = json.Unmarshal(content, file)
+	default:
+		err = NewErrUnsupportedFileFormat(ext)
+	}
+	if err != nil {
+		return nil, merror.Stack(fmt.Errorf("%s: %w", path, err))
+	}
+
+	domain, err := file.ToDomain()
+	if err != nil {
+		return nil, merror.Stack(fmt.Errorf("%s: %w", path, err))
+	}
+
+	// templates are looked up next to the definition file
+	if domain.Configuration != nil && domain.Configuration.TemplatesPath != "" && !filepath.IsAbs(domain.Configuration.TemplatesPath) {
+		domain.Configuration.TemplatesPath = filepath.Join(filepath.Dir(path), domain.Configuration.TemplatesPath)
+	}
+	return domain, nil
+}
+
+// ToDomain resolves model names into the pointer graph expected by the generator.
+func (file *DomainFile) ToDomain() (*Domain, error) {
+	domain := &Domain{
+		Name: file.Name,
+		Controllers: Controllers{
+			Http: file.Controllers.Http,
+		},
+	}
+	if file.Configuration != nil {
+		domain.Configuration = configurationFromFile(file.Configuration)
+	}
+
+	// named types are created first so fields and validations can reference any of them
+	types := &fileTypes{
+		models:       map[string]*Model{},
+		enums:        map[string]*Enum{},
+		valueObjects: map[string]*ValueObject{},
+		external:     map[string]*ExternalType{},
+	}
+	models := types.models
+	for _, m := range file.Models {
+		model := NewModel(m.Name)
+		model.Activable = m.Activable
+		model.IDStrategy = m.IDStrategy
+		if m.Archivable != nil {
+			model.Archivable = *m.Archivable
+		}
+		models[m.Name] = model
+		domain.Models = append(domain.Models, model)
+	}
+	for _, e := range file.Enums {
+		enum := &Enum{
+			Name:   e.Name,
+			Values: e.Values,
+		}
+		types.enums[e.Name] = enum
+		domain.Enums = append(domain.Enums, enum)
+	}
+	for _, vo := range file.ValueObjects {
+		valueObject := &ValueObject{
+			Name: vo.Name,
+		}
+		types.valueObjects[vo.Name] = valueObject
+		domain.ValueObjects = append(domain.ValueObjects, valueObject)
+	}
+
+	for _, t := range file.ExternalTypes {
+		external := &ExternalType{
+			Package:            t.Package,
+			Name:               t.Name,
+			Representation:     t.Representation,
+			ToRepresentation:   t.ToRepresentation,
+			FromRepresentation: t.FromRepresentation,
+		}
+		types.external[t.Name] = external
+		domain.ExternalTypes = append(domain.ExternalTypes, external)
+	}
+
+	for i, vo := range file.ValueObjects {
+		fields, err := fieldsFromFile(types, vo.Fields)
+		if err != nil {
+			return nil, merror.Stack(fmt.Errorf("value object %s: %w", vo.Name, err))
+		}
+		domain.ValueObjects[i].Fields = fields
+	}
+
+	for i, m := range file.Models {
+		fields, err := fieldsFromFile(types, m.Fields)
+		if err != nil {
+			return nil, merror.Stack(fmt.Errorf("model %s: %w", m.Name, err))
+		}
+		domain.Models[i].Fields = fields
+		domain.Models[i].Validations = fieldsValidationsFromFile(m.Validations)
+	}
+
+	for _, r := range file.Relations {
+		source, err := getModel(models, r.Source)
+		if err != nil {
+			return nil, merror.Stack(err)
+		}
+		target, err := getModel(models, r.Target)
+		if err != nil {
+			return nil, merror.Stack(err)
+		}
+		domain.Relations = append(domain.Relations, &Relation{
+			Source:        source,
+			Target:        target,
+			Type:          r.Type,
+			IgnoreReverse: r.IgnoreReverse,
+		})
+	}
+
+	for _, r := range file.Repositories {
+		on, err := getModel(models, r.On)
+		if err != nil {
+			return nil, merror.Stack(err)
+		}
+		repository := &Repository{
+			On:             on,
+			TableName:      r.TableName,
+			DefaultOrderBy: r.DefaultOrderBy,
+		}
+		for _, m := range r.Methods {
+			params, err := paramsFromFile(types, m.Params)
+			if err != nil {
+				return nil, merror.Stack(fmt.Errorf("repository %s method %s: %w", r.On, m.Name, err))
+			}
+			results := []Type{}
+			for _, result := range m.Results {
+				t, err := typeFromFile(types, result)
+				if err != nil {
+					return nil, merror.Stack(fmt.Errorf("repository %s method %s: %w", r.On, m.Name, err))
+				}
+				results = append(results, t)
+			}
+			repository.Methods = append(repository.Methods, &RepositoryMethod{
+				Name:      m.Name,
+				Params:    params,
+				Results:   results,
+				Paginable: m.Paginable,
+			})
+		}
+		domain.Repositories = append(domain.Repositories, repository)
+	}
+
+	for _, u := range file.Usecases {
+		args, err := paramsFromFile(types, u.Args)
+		if err != nil {
+			return nil, merror.Stack(fmt.Errorf("usecase %s: %w", u.Name, err))
+		}
+		results, err := paramsFromFile(types, u.Results)
+		if err != nil {
+			return nil, merror.Stack(fmt.Errorf("usecase %s: %w", u.Name, err))
+		}
+		domain.Usecases = append(domain.Usecases, &Usecase{
+			Name:        u.Name,
+			Args:        args,
+			Results:     results,
+			Roles:       u.Roles,
+			Validations: fieldsValidationsFromFile(u.Validations),
+		})
+	}
+
+	for _, c := range file.CRUDs {
+		on, err := getModel(models, c.On)
+		if err != nil {
+			return nil, merror.Stack(err)
+		}
+		crud := &CRUD{
+			On:         on,
+			Create:     c.Create,
+			Get:        c.Get,
+			GetActive:  c.GetActive,
+			List:       c.List,
+			ListActive: c.ListActive,
+			Update:     c.Update,
+			Delete:     c.Delete,
+		}
+		for _, rc := range c.RelationCRUDs {
+			relation, err := getRelation(domain.Relations, rc.Source, rc.Target, rc.Type)
+			if err != nil {
+				return nil, merror.Stack(err)
+			}
+			crud.RelationCRUDs = append(crud.RelationCRUDs, &RelationCRUD{
+				Relation: relation,
+				Roles:    rc.Roles,
+				Add:      rc.Add,
+				Remove:   rc.Remove,
+				List:     rc.List,
+			})
+		}
+		domain.CRUDs = append(domain.CRUDs, crud)
+	}
+
+	return domain, nil
+}
+
+func getModel(models map[string]*Model, name string) (*Model, error) {
+	model, ok := models[name]
+	if !ok {
+		return nil, NewErrModelNotFound(name)
+	}
+	return model, nil
+}
+
+// getRelation returns the relation from source to target, of type relationType when set
+func getRelation(relations []*Relation, source string, target string, relationType RelationType) (*Relation, error) {
+	var found *Relation
+	for _, relation := range relations {
+		if relation.Source.Name != source || relation.Target.Name != target {
+			continue
+		}
+		if relationType != "" && relation.Type != relationType {
+			continue
+		}
+		if found != nil {
+			return nil, NewErrAmbiguousRelation(source, target)
+		}
+		found = relation
+	}
+	if found == nil {
+		return nil, NewErrRelationNotFound(source, target)
+	}
+	return found, nil
+}
+
+func configurationFromFile(file *DomainConfigurationFile) *DomainConfiguration {
+	return &DomainConfiguration{
+		DefaultOrderBy:         file.DefaultOrderBy,
+		Package:                file.Package,
+		DomainFolder:           file.DomainFolder,
+		ModelFolder:            file.ModelFolder,
+		UsecaseFolder:          file.UsecaseFolder,
+		PortFolder:             file.PortFolder,
+		AdapterFolder:          file.AdapterFolder,
+		ControllerFolder:       file.ControllerFolder,
+		RepositoryFolder:       file.RepositoryFolder,
+		ConstsFolder:           file.ConstsFolder,
+		SdkFolder:              file.SdkFolder,
+		ClientFolder:           file.ClientFolder,
+		JavascriptClientFolder: file.JavascriptClientFolder,
+		GormFolder:             file.GormFolder,
+		HttpFolder:             file.HttpFolder,
+		DomainPath:             file.DomainPath,
+		UsecasePath:            file.UsecasePath,
+		ModelPath:              file.ModelPath,
+		PortPath:               file.PortPath,
+		ControllerPath:         file.ControllerPath,
+		RepositoryPath:         file.RepositoryPath,
+		AdapterPath:            file.AdapterPath,
+		SdkPath:                file.SdkPath,
+		TemplatesPath:          file.TemplatesPath,
+		IDStrategy:             file.IDStrategy,
+		Dialect:                file.Dialect,
+	}
+}
+
+// fileTypes are the named types of a definition file
+type fileTypes struct {
+	models       map[string]*Model
+	enums        map[string]*Enum
+	valueObjects map[string]*ValueObject
+	external     map[string]*ExternalType
+}
+
+func fieldsFromFile(types *fileTypes, files []*FieldFile) ([]*Field, error) {
+	fields := []*Field{}
+	for _, f := range files {
+		t, err := typeFromFile(types, f.Type)
+		if err != nil {
+			return nil, merror.Stack(fmt.Errorf("field %s: %w", f.Name, err))
+		}
+		validations, err := validationsFromFile(types.models, f.Validations)
+		if err != nil {
+			return nil, merror.Stack(fmt.Errorf("field %s: %w", f.Name, err))
+		}
+		fields = append(fields, &Field{
+			Name:        f.Name,
+			Type:        t,
+			Validations: validations,
+			Optional:    f.Optional,
+			Precision:   f.Precision,
+			Scale:       f.Scale,
+			Default:     defaultFromFile(f.Default),
+		})
+	}
+	return fields, nil
+}
+
+func paramsFromFile(types *fileTypes, files []*FieldFile) ([]*Param, error) {
+	fields, err := fieldsFromFile(types, files)
+	if err != nil {
+		return nil, merror.Stack(err)
+	}
+	params := []*Param{}
+	for _, field := range fields {
+		if field.Default != nil {
+			return nil, merror.Stack(NewErrUnexpectedDefault(field.Name))
+		}
+		params = append(params, &Param{
+			Name:        field.Name,
+			Type:        field.Type,
+			Validations: field.Validations,
+			Optional:    field.Optional,
+			Precision:   field.Precision,
+			Scale:       field.Scale,
+		})
+	}
+	return params, nil
+}
+
+// defaultFromFile returns the generator named by value, value itself otherwise
+func defaultFromFile(value interface{}) interface{} {
+	if name, ok := value.(string); ok && slices.Contains(DEFAULT_GENERATORS, DefaultGenerator(name)) {
+		return DefaultGenerator(name)
+	}
+	return value
+}
+
+func typeFromFile(types *fileTypes, t string) (Type, error) {
+	if strings.HasPrefix(t, ARRAY_TYPE_PREFIX) {
+		subType, err := typeFromFile(types, strings.TrimPrefix(t, ARRAY_TYPE_PREFIX))
+		if err != nil {
+			return nil, merror.Stack(err)
+		}
+		return &Array{Type: subType}, nil
+	}
+
+	if strings.HasPrefix(t, MAP_TYPE_PREFIX) {
+		key, value, ok := strings.Cut(strings.TrimPrefix(t, MAP_TYPE_PREFIX), "]")
+		if !ok {
+			return nil, NewErrUnknownType(t)
+		}
+		keyType, err := typeFromFile(types, key)
+		if err != nil {
+			return nil, merror.Stack(err)
+		}
+		valueType, err := typeFromFile(types, value)
+		if err != nil {
+			return nil, merror.Stack(err)
+		}
+		return &Map{Key: keyType, Value: valueType}, nil
+	}
+
+	switch PrimitiveType(t) {
+	case PrimitiveTypeInt, PrimitiveTypeFloat, PrimitiveTypeString, PrimitiveTypeBool, PrimitiveTypeByte,
+		PrimitiveTypeBytes, PrimitiveTypeDate, PrimitiveTypeDateTime, PrimitiveTypeTime, PrimitiveTypeFile,
+		PrimitiveTypeDecimal, PrimitiveTypeMoney, PrimitiveTypeJSON:
+		return PrimitiveType(t), nil
+	}
+
+	if model, ok := types.models[t]; ok {
+		return model, nil
+	}
+	if enum, ok := types.enums[t]; ok {
+		return enum, nil
+	}
+	if valueObject, ok := types.valueObjects[t]; ok {
+		return valueObject, nil
+	}
+	if external, ok := types.external[t]; ok {
+		return external, nil
+	}
+	return nil, NewErrUnknownType(t)
+}
+
+func fieldsValidationsFromFile(files []*FieldsValidationFile) []*FieldsValidation {
+	validations := []*FieldsValidation{}
+	for _, v := range files {
+		validations = append(validations, &FieldsValidation{
+			Rule:    v.Rule,
+			Fields:  v.Fields,
+			Message: v.Message,
+		})
+	}
+	return validations
+}
+
+func validationsFromFile(models map[string]*Model, files []*ValidationFile) ([]*Validation, error) {
+	validations := []*Validation{}
+	for _, v := range files {
+		validation := &Validation{
+			Rule: v.Rule,
+		}
+		switch value := v.Value.(type) {
+		case nil:
+		case string:
+			if v.Rule == ValidationRuleUniqueIn {
+				model, err := getModel(models, value)
+				if err != nil {
+					return nil, merror.Stack(err)
+				}
+				validation.Value = model
+			} else {
+				validation.Value = value
+			}
+		case []interface{}:
+			values := []string{}
+			for _, item := range value {
+				values = append(values, fmt.Sprint(item))
+			}
+			validation.Value = values
+		case int:
+			validation.Value = strconv.Itoa(value)
+		case float64:
+			validation.Value = strconv.FormatFloat(value, 'f', -1, 64)
+		default:
+			validation.Value = value
+		}
+		validations = append(validations, validation)
+	}
+	return validations, nil
+}
@@ -0,0 +1,279 @@
+package coredomaindefinition
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTypeFromFile(t *testing.T) {
+	types := &fileTypes{
+		models:       map[string]*Model{"user": NewModel("user")},
+		enums:        map[string]*Enum{"status": {Name: "status"}},
+		valueObjects: map[string]*ValueObject{"address": {Name: "address"}},
+		external:     map[string]*ExternalType{"Duration": {Name: "Duration"}},
+	}
+
+	tests := []struct {
+		name     string
+		t        string
+		expected Type
+		err      string
+	}{
+		{name: "primitive", t: "string", expected: PrimitiveTypeString},
+		{name: "decimal", t: "decimal", expected: PrimitiveTypeDecimal},
+		{name: "model", t: "user", expected: types.models["user"]},
+		{name: "enum", t: "status", expected: types.enums["status"]},
+		{name: "value object", t: "address", expected: types.valueObjects["address"]},
+		{name: "external", t: "Duration", expected: types.external["Duration"]},
+		{name: "array", t: "[]int", expected: &Array{Type: PrimitiveTypeInt}},
+		{name: "nested array", t: "[][]status", expected: &Array{Type: &Array{Type: types.enums["status"]}}},
+		{name: "map", t: "map[string]int", expected: &Map{Key: PrimitiveTypeString, Value: PrimitiveTypeInt}},
+		{name: "map of arrays", t: "map[status][]string", expected: &Map{Key: types.enums["status"], Value: &Array{Type: PrimitiveTypeString}}},
+		{name: "unknown", t: "uint", err: "unknown type ‘uint’"},
+		{name: "unknown array element", t: "[]uint", err: "unknown type ‘uint’"},
+		{name: "unclosed map", t: "map[string", err: "unknown type ‘map[string’"},
+		{name: "unknown map value", t: "map[string]uint", err: "unknown type ‘uint’"},
+		{name: "empty", t: "", err: "unknown type ‘’"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := typeFromFile(types, test.t)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Fatalf("expected %#v, got %#v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestValidationsFromFile(t *testing.T) {
+	models := map[string]*Model{"shop": NewModel("shop")}
+
+	tests := []struct {
+		name     string
+		file     *ValidationFile
+		expected interface{}
+		err      string
+	}{
+		{name: "no value", file: &ValidationFile{Rule: ValidationRuleRequired}, expected: nil},
+		{name: "string", file: &ValidationFile{Rule: ValidationRulePattern, Value: "^[a-z]+$"}, expected: "^[a-z]+$"},
+		{name: "int", file: &ValidationFile{Rule: ValidationRuleMinLength, Value: 3}, expected: "3"},
+		{name: "float", file: &ValidationFile{Rule: ValidationRuleGT, Value: 1.5}, expected: "1.5"},
+		{name: "list", file: &ValidationFile{Rule: ValidationRuleOneOf, Value: []interface{}{"a", 2}}, expected: []string{"a", "2"}},
+		{name: "uniqueIn", file: &ValidationFile{Rule: ValidationRuleUniqueIn, Value: "shop"}, expected: models["shop"]},
+		{name: "uniqueIn unknown model", file: &ValidationFile{Rule: ValidationRuleUniqueIn, Value: "user"}, err: "model 'user' not found"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			validations, err := validationsFromFile(models, []*ValidationFile{test.file})
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if validations[0].Rule != test.file.Rule || !reflect.DeepEqual(validations[0].Value, test.expected) {
+				t.Fatalf("expected %s %#v, got %s %#v", test.file.Rule, test.expected, validations[0].Rule, validations[0].Value)
+			}
+		})
+	}
+}
+
+func TestToDomain(t *testing.T) {
+	tests := []struct {
+		name string
+		file *DomainFile
+		err  string
+	}{
+		{
+			name: "relation on unknown model",
+			file: &DomainFile{
+				Models:    []*ModelFile{{Name: "user"}},
+				Relations: []*RelationFile{{Source: "user", Target: "shop", Type: RelationTypeManyToOne}},
+			},
+			err: "model 'shop' not found",
+		},
+		{
+			name: "unknown field type",
+			file: &DomainFile{
+				Models: []*ModelFile{{Name: "user", Fields: []*FieldFile{{Name: "age", Type: "uint"}}}},
+			},
+			err: "model user: field age: unknown type ‘uint’",
+		},
+		{
+			name: "unknown usecase arg type",
+			file: &DomainFile{
+				Usecases: []*UsecaseFile{{Name: "login", Args: []*FieldFile{{Name: "code", Type: "otp"}}}},
+			},
+			err: "usecase login: field code: unknown type ‘otp’",
+		},
+		{
+			name: "default on a usecase arg",
+			file: &DomainFile{
+				Usecases: []*UsecaseFile{{Name: "login", Args: []*FieldFile{{Name: "code", Type: "string", Default: "x"}}}},
+			},
+			err: "'code' can not have a default, only model fields can",
+		},
+		{
+			name: "crud on undefined relation",
+			file: &DomainFile{
+				Models: []*ModelFile{{Name: "user"}, {Name: "shop"}},
+				CRUDs:  []*CRUDFile{{On: "user", RelationCRUDs: []*RelationCRUDFile{{Source: "user", Target: "shop"}}}},
+			},
+			err: "relation between 'user' and 'shop' not found",
+		},
+		{
+			name: "crud on a relation of another type",
+			file: &DomainFile{
+				Models:    []*ModelFile{{Name: "user"}, {Name: "shop"}},
+				Relations: []*RelationFile{{Source: "user", Target: "shop", Type: RelationTypeManyToOne}},
+				CRUDs:     []*CRUDFile{{On: "user", RelationCRUDs: []*RelationCRUDFile{{Source: "user", Target: "shop", Type: RelationTypeManyToMany}}}},
+			},
+			err: "relation between 'user' and 'shop' not found",
+		},
+		{
+			name: "crud on several relations",
+			file: &DomainFile{
+				Models: []*ModelFile{{Name: "user"}, {Name: "shop"}},
+				Relations: []*RelationFile{
+					{Source: "user", Target: "shop", Type: RelationTypeManyToOne},
+					{Source: "user", Target: "shop", Type: RelationTypeManyToMany},
+				},
+				CRUDs: []*CRUDFile{{On: "user", RelationCRUDs: []*RelationCRUDFile{{Source: "user", Target: "shop"}}}},
+			},
+			err: "several relations between 'user' and 'shop', the relation type is required",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.file.ToDomain()
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Fatalf("expected error %q, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestToDomainResolvesReferences(t *testing.T) {
+	archivable := false
+	file := &DomainFile{
+		Name: "shop",
+		Models: []*ModelFile{
+			{Name: "user", Archivable: &archivable, Fields: []*FieldFile{{Name: "shop", Type: "shop"}}},
+			{Name: "shop", Fields: []*FieldFile{{Name: "status", Type: "status", Optional: true}}},
+		},
+		Enums: []*EnumFile{{Name: "status", Values: []string{"open", "closed"}}},
+		Relations: []*RelationFile{
+			{Source: "user", Target: "shop", Type: RelationTypeManyToOne},
+			{Source: "user", Target: "shop", Type: RelationTypeManyToMany},
+		},
+		CRUDs:         []*CRUDFile{{On: "user", RelationCRUDs: []*RelationCRUDFile{{Source: "user", Target: "shop", Type: RelationTypeManyToMany}}}},
+		Configuration: &DomainConfigurationFile{Package: "github.com/acme/shop", Dialect: DialectPostgres},
+		ExternalTypes: []*ExternalTypeFile{{Package: "net/netip", Name: "Addr", ToRepresentation: "ToString", FromRepresentation: "FromString"}},
+	}
+
+	domain, err := file.ToDomain()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	user, shop := domain.Models[0], domain.Models[1]
+	if user.Archivable || !shop.Archivable {
+		t.Fatalf("expected archivable to default to true when omitted, got user %v shop %v", user.Archivable, shop.Archivable)
+	}
+	if user.Fields[0].Type != shop {
+		t.Fatalf("expected the field to reference the model defined after it")
+	}
+	if shop.Fields[0].Type != domain.Enums[0] || !shop.Fields[0].Optional {
+		t.Fatalf("expected an optional field of the enum, got %#v", shop.Fields[0])
+	}
+	if domain.Relations[0].Source != user || domain.Relations[0].Target != shop {
+		t.Fatalf("expected the relation to reference the models")
+	}
+	if domain.CRUDs[0].On != user || domain.CRUDs[0].RelationCRUDs[0].Relation != domain.Relations[1] {
+		t.Fatalf("expected the crud to reference the model and the relation of its type")
+	}
+	if domain.Configuration.Package != "github.com/acme/shop" || domain.Configuration.Dialect != DialectPostgres {
+		t.Fatalf("expected the configuration of the file, got %#v", domain.Configuration)
+	}
+	expected := &ExternalType{Package: "net/netip", Name: "Addr", ToRepresentation: "ToString", FromRepresentation: "FromString"}
+	if !reflect.DeepEqual(domain.ExternalTypes[0], expected) {
+		t.Fatalf("expected %#v, got %#v", expected, domain.ExternalTypes[0])
+	}
+}
+
+func TestLoadDomainFile(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		err     string
+	}{
+		{
+			name:    "yaml",
+			file:    "domain.yaml",
+			content: "name: shop\nconfiguration:\n  templatesPath: templates\nmodels:\n  - name: user\n    fields:\n      - name: tags\n        type: '[]string'\n",
+		},
+		{
+			name:    "yml",
+			file:    "domain.yml",
+			content: "name: shop\nconfiguration:\n  templatesPath: templates\nmodels:\n  - name: user\n    fields:\n      - name: tags\n        type: '[]string'\n",
+		},
+		{
+			name:    "json",
+			file:    "domain.json",
+			content: `{"name": "shop", "configuration": {"templatesPath": "templates"}, "models": [{"name": "user", "fields": [{"name": "tags", "type": "[]string"}]}]}`,
+		},
+		{name: "unsupported format", file: "domain.toml", content: "name = 'shop'", err: "domain.toml: unsupported definition file format '.toml'"},
+		{name: "invalid yaml", file: "invalid.yaml", content: "name: [shop", err: "invalid.yaml: "},
+		{name: "unknown type", file: "unknown.yaml", content: "models:\n  - name: user\n    fields:\n      - name: age\n        type: uint\n", err: "unknown.yaml: model user: field age: unknown type ‘uint’"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(dir, test.file)
+			if err := os.WriteFile(path, []byte(test.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			domain, err := LoadDomainFile(path)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if domain.Name != "shop" || len(domain.Models) != 1 {
+				t.Fatalf("unexpected domain %#v", domain)
+			}
+			if !reflect.DeepEqual(domain.Models[0].Fields[0].Type, &Array{Type: PrimitiveTypeString}) {
+				t.Fatalf("expected an array of strings, got %#v", domain.Models[0].Fields[0].Type)
+			}
+			// templates are relative to the definition file
+			if domain.Configuration.TemplatesPath != filepath.Join(dir, "templates") {
+				t.Fatalf("expected templates next to the file, got %s", domain.Configuration.TemplatesPath)
+			}
+		})
+	}
+
+	if _, err := LoadDomainFile(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Fatal("expected an error on a missing file")
+	}
+}
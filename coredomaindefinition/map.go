@@ -0,0 +1,11 @@
+package coredomaindefinition
+
+// Map is a key value bag, stored as a JSON column
+type Map struct {
+	Key   Type
+	Value Type
+}
+
+func (t Map) GetType() string {
+	return "map"
+}
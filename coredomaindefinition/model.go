@@ -5,6 +5,10 @@ type Model struct {
 	Fields     []*Field
 	Activable  bool
 	Archivable bool
+	// IDStrategy of the primary key, the one of the domain configuration when empty
+	IDStrategy IDStrategy
+	// Validations on several fields, checked on create and update
+	Validations []*FieldsValidation
 }
 
 func (m Model) GetType() string {
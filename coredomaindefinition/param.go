@@ -4,4 +4,21 @@ type Param struct {
 	Name        string
 	Type        Type
 	Validations []*Validation
+	// Optional params can be omitted, they are pointers in the usecase requests and responses
+	Optional bool
+	// Precision and Scale of decimal params, DEFAULT_DECIMAL_PRECISION and DEFAULT_DECIMAL_SCALE when zero
+	Precision int
+	Scale     int
+}
+
+// ToField returns the field holding the param in a usecase request or response
+func (p *Param) ToField() *Field {
+	return &Field{
+		Name:        p.Name,
+		Type:        p.Type,
+		Validations: p.Validations,
+		Optional:    p.Optional,
+		Precision:   p.Precision,
+		Scale:       p.Scale,
+	}
 }
@@ -1,7 +1,16 @@
 package coredomaindefinition
 
 type Platform struct {
-	Name    string
+	Name string
+	// Optionnal: module path of the shared platform packages, Name is used if empty
+	Package string
 	Domains []*Domain
 	Roles   []string
 }
+
+func (platform *Platform) GetPackage() string {
+	if platform.Package == "" {
+		return platform.Name
+	}
+	return platform.Package
+}
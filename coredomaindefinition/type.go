@@ -18,8 +18,24 @@ const (
 	PrimitiveTypeDateTime PrimitiveType = "datetime"
 	PrimitiveTypeTime     PrimitiveType = "time"
 	PrimitiveTypeFile     PrimitiveType = "file"
+	PrimitiveTypeDecimal  PrimitiveType = "decimal"
+	// PrimitiveTypeMoney is an amount with its ISO 4217 currency
+	PrimitiveTypeMoney PrimitiveType = "money"
+	// PrimitiveTypeJSON is a raw JSON document, kept as is
+	PrimitiveTypeJSON PrimitiveType = "json"
 )
 
+// Precision and scale of decimal fields that do not set them
+const (
+	DEFAULT_DECIMAL_PRECISION = 20
+	DEFAULT_DECIMAL_SCALE     = 4
+	MAX_DECIMAL_PRECISION     = 1000
+)
+
+// DECIMAL_PATTERN matches the text of a decimal, digits with an optional minus sign and fraction.
+// Exponents, hexadecimal floats and underscores accepted by big.Rat are not decimals.
+const DECIMAL_PATTERN = `^-?\d+(\.\d+)?$`
+
 func (t PrimitiveType) GetType() string {
 	return string(t)
 }
@@ -5,4 +5,6 @@ type Usecase struct {
 	Args    []*Param
 	Results []*Param
 	Roles   []string
+	// Validations on several args
+	Validations []*FieldsValidation
 }
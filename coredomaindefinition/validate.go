@@ -0,0 +1,878 @@
+package coredomaindefinition
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/cleogithub/golem-common/pkg/stringtool"
+)
+
+// DefinitionError is a problem found in a definition, located by its path, e.g. domain.models[Order].fields[total]
+type DefinitionError struct {
+	Path string
+	Err  error
+}
+
+func (e *DefinitionError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *DefinitionError) Unwrap() error {
+	return e.Err
+}
+
+// DefinitionErrors aggregates every problem found in a definition
+type DefinitionErrors []*DefinitionError
+
+func (errs DefinitionErrors) Error() string {
+	lines := []string{}
+	for _, err := range errs {
+		lines = append(lines, err.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Validate checks the semantic of the domain before generation.
+// Every problem found is returned at once as DefinitionErrors, nil if the domain is valid.
+func (domain *Domain) Validate() error {
+	v := &domainValidator{
+		domain:       domain,
+		models:       map[*Model]bool{},
+		enums:        map[*Enum]bool{},
+		valueObjects: map[*ValueObject]bool{},
+		external:     map[*ExternalType]bool{},
+		repositories: map[*Model]bool{},
+	}
+	v.validate()
+
+	if len(v.errs) > 0 {
+		return v.errs
+	}
+	return nil
+}
+
+type domainValidator struct {
+	domain       *Domain
+	models       map[*Model]bool
+	enums        map[*Enum]bool
+	valueObjects map[*ValueObject]bool
+	external     map[*ExternalType]bool
+	repositories map[*Model]bool
+	errs         DefinitionErrors
+}
+
+func (v *domainValidator) add(path string, err error) {
+	v.errs = append(v.errs, &DefinitionError{Path: path, Err: err})
+}
+
+func (v *domainValidator) validate() {
+	path := "domain"
+	if v.domain.Name == "" {
+		v.add(path+".name", NewErrRequired("name"))
+	}
+	if v.domain.Configuration == nil || v.domain.Configuration.Package == "" {
+		v.add(path+".configuration.package", NewErrRequired("package"))
+	}
+	if v.domain.Configuration != nil {
+		v.validateIDStrategy(path+".configuration.idStrategy", v.domain.Configuration.IDStrategy)
+		if dialect := v.domain.Configuration.Dialect; dialect != "" && !slices.Contains([]Dialect{
+			DialectPostgres,
+			DialectMySQL,
+			DialectSQLite,
+		}, dialect) {
+			v.add(path+".configuration.dialect", NewErrUnknownDialect(string(dialect)))
+		}
+	}
+
+	names := map[string]bool{}
+	for _, m := range v.domain.Models {
+		if names[m.Name] {
+			v.add(fmt.Sprintf("%s.models[%s]", path, m.Name), NewErrDuplicateDefinition(m.Name))
+		}
+		names[m.Name] = true
+		v.models[m] = true
+	}
+
+	// enums and models share the type names
+	for _, e := range v.domain.Enums {
+		enumPath := fmt.Sprintf("%s.enums[%s]", path, e.Name)
+		if e.Name == "" {
+			v.add(enumPath+".name", NewErrRequired("name"))
+		} else if names[e.Name] {
+			v.add(enumPath, NewErrDuplicateDefinition(e.Name))
+		}
+		names[e.Name] = true
+		v.enums[e] = true
+		v.validateEnum(enumPath, e)
+	}
+
+	for _, vo := range v.domain.ValueObjects {
+		voPath := fmt.Sprintf("%s.valueObjects[%s]", path, vo.Name)
+		if vo.Name == "" {
+			v.add(voPath+".name", NewErrRequired("name"))
+		} else if names[vo.Name] {
+			v.add(voPath, NewErrDuplicateDefinition(vo.Name))
+		}
+		names[vo.Name] = true
+		v.valueObjects[vo] = true
+	}
+	for _, t := range v.domain.ExternalTypes {
+		externalPath := fmt.Sprintf("%s.externalTypes[%s]", path, t.Name)
+		if t.Name == "" {
+			v.add(externalPath+".name", NewErrRequired("name"))
+		} else if names[t.Name] {
+			v.add(externalPath, NewErrDuplicateDefinition(t.Name))
+		}
+		names[t.Name] = true
+		v.external[t] = true
+		v.validateExternalType(externalPath, t)
+	}
+
+	v.validateEnumConstNames(path)
+
+	for _, vo := range v.domain.ValueObjects {
+		v.validateValueObject(fmt.Sprintf("%s.valueObjects[%s]", path, vo.Name), vo)
+	}
+
+	for _, r := range v.domain.Relations {
+		v.validateRelation(fmt.Sprintf("%s.relations[%s]", path, relationName(r)), r)
+	}
+
+	for _, m := range v.domain.Models {
+		v.validateModel(fmt.Sprintf("%s.models[%s]", path, m.Name), m)
+	}
+
+	for _, r := range v.domain.Repositories {
+		v.validateRepository(fmt.Sprintf("%s.repositories[%s]", path, modelName(r.On)), r)
+	}
+
+	for _, c := range v.domain.CRUDs {
+		v.validateCRUD(fmt.Sprintf("%s.cruds[%s]", path, modelName(c.On)), c)
+	}
+
+	names = map[string]bool{}
+	for _, u := range v.domain.Usecases {
+		usecasePath := fmt.Sprintf("%s.usecases[%s]", path, u.Name)
+		if u.Name == "" {
+			v.add(usecasePath+".name", NewErrRequired("name"))
+		} else if names[u.Name] {
+			v.add(usecasePath, NewErrDuplicateDefinition(u.Name))
+		}
+		names[u.Name] = true
+		v.validateParams(usecasePath+".args", u.Args)
+		v.validateParams(usecasePath+".results", u.Results)
+
+		args := []*Field{}
+		for _, arg := range u.Args {
+			args = append(args, arg.ToField())
+		}
+		v.validateFieldsValidations(usecasePath, args, u.Validations)
+	}
+}
+
+func (v *domainValidator) validateModel(path string, m *Model) {
+	if m.Name == "" {
+		v.add(path+".name", NewErrRequired("name"))
+	}
+	v.validateIDStrategy(path+".idStrategy", m.IDStrategy)
+
+	names := map[string]bool{}
+	for _, f := range m.Fields {
+		fieldPath := fmt.Sprintf("%s.fields[%s]", path, f.Name)
+		if f.Name == "" {
+			v.add(fieldPath+".name", NewErrRequired("name"))
+		} else if names[f.Name] {
+			v.add(fieldPath, NewErrDuplicateDefinition(f.Name))
+		}
+		names[f.Name] = true
+
+		v.validateType(fieldPath+".type", f.Type)
+		v.validateValidations(fieldPath, m, f.Type, f.Validations)
+		v.validateOptional(fieldPath, f.Name, f.Optional, f.Validations)
+		v.validateDecimal(fieldPath, f.Type, f.Precision, f.Scale)
+		v.validateDefault(fieldPath, f)
+	}
+	v.validateFieldsValidations(path, m.Fields, m.Validations)
+}
+
+func (v *domainValidator) validateEnum(path string, e *Enum) {
+	if len(e.Values) == 0 {
+		v.add(path+".values", NewErrRequired("values"))
+	}
+
+	values := map[string]bool{}
+	// values generating the same go constant or javascript key
+	constNames := map[string]string{}
+	jsKeys := map[string]string{}
+	for _, value := range e.Values {
+		valuePath := fmt.Sprintf("%s.values[%s]", path, value)
+		if values[value] {
+			v.add(valuePath, NewErrDuplicateDefinition(value))
+			continue
+		}
+		values[value] = true
+
+		constName, jsKey := GetEnumValueConstName(e, value), GetEnumValueJSKey(value)
+		if other, ok := constNames[constName]; ok {
+			v.add(valuePath, NewErrIdentifierCollision(value, other, constName))
+		} else if other, ok := jsKeys[jsKey]; ok {
+			v.add(valuePath, NewErrIdentifierCollision(value, other, jsKey))
+		}
+		constNames[constName] = value
+		jsKeys[jsKey] = value
+
+		// values are used in go constant names, validate tags and SQL check constraints
+		valid := strings.ContainsFunc(value, func(r rune) bool {
+			return unicode.IsLetter(r) || unicode.IsDigit(r)
+		})
+		for _, r := range value {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("_-.", r) {
+				valid = false
+			}
+		}
+		if !valid {
+			v.add(valuePath, NewErrInvalidEnumValue(value))
+		}
+	}
+}
+
+// validateEnumConstNames reports enum constants colliding with the constants of another enum or with a type of the model package,
+// e.g. "status" of order and the enum orderStatus both generate OrderStatus
+func (v *domainValidator) validateEnumConstNames(path string) {
+	identifiers := map[string]string{}
+	for _, m := range v.domain.Models {
+		identifiers[stringtool.UpperFirstLetter(m.Name)] = m.Name
+	}
+	for _, e := range v.domain.Enums {
+		identifiers[stringtool.UpperFirstLetter(e.Name)] = e.Name
+	}
+	for _, vo := range v.domain.ValueObjects {
+		identifiers[stringtool.UpperFirstLetter(vo.Name)] = vo.Name
+	}
+
+	for _, e := range v.domain.Enums {
+		constNames := map[string]string{}
+		for _, value := range e.Values {
+			constName := GetEnumValueConstName(e, value)
+			if other, ok := identifiers[constName]; ok {
+				v.add(fmt.Sprintf("%s.enums[%s].values[%s]", path, e.Name, value), NewErrIdentifierCollision(value, other, constName))
+			}
+			constNames[constName] = e.Name + "." + value
+		}
+		// collisions inside the enum are reported by validateEnum
+		for constName, name := range constNames {
+			identifiers[constName] = name
+		}
+	}
+}
+
+func (v *domainValidator) validateValueObject(path string, vo *ValueObject) {
+	if len(vo.Fields) == 0 {
+		v.add(path+".fields", NewErrRequired("fields"))
+	}
+
+	names := map[string]bool{}
+	for _, f := range vo.Fields {
+		fieldPath := fmt.Sprintf("%s.fields[%s]", path, f.Name)
+		if f.Name == "" {
+			v.add(fieldPath+".name", NewErrRequired("name"))
+		} else if names[f.Name] {
+			v.add(fieldPath, NewErrDuplicateDefinition(f.Name))
+		}
+		names[f.Name] = true
+
+		// value objects are flattened in the columns of the model using them
+		switch t := f.Type.(type) {
+		case *Model, *ValueObject, *Array, *Map:
+			v.add(fieldPath+".type", NewErrUnsupportedValueObjectField(f.Type.GetType()))
+		case *ExternalType:
+			// converters are applied by the gorm adapter on model fields only
+			if t.HasConverters() {
+				v.add(fieldPath+".type", NewErrUnsupportedValueObjectField(f.Type.GetType()))
+			} else {
+				v.validateType(fieldPath+".type", f.Type)
+			}
+		case PrimitiveType:
+			if f.Type == PrimitiveTypeMoney {
+				v.add(fieldPath+".type", NewErrUnsupportedValueObjectField(f.Type.GetType()))
+			} else {
+				v.validateType(fieldPath+".type", f.Type)
+			}
+		default:
+			v.validateType(fieldPath+".type", f.Type)
+		}
+		v.validateValidations(fieldPath, nil, f.Type, f.Validations)
+		v.validateOptional(fieldPath, f.Name, f.Optional, f.Validations)
+		v.validateDecimal(fieldPath, f.Type, f.Precision, f.Scale)
+		if f.Default != nil {
+			v.add(fieldPath+".default", NewErrUnexpectedDefault(f.Name))
+		}
+	}
+}
+
+func (v *domainValidator) validateExternalType(path string, t *ExternalType) {
+	if t.Package == "" {
+		v.add(path+".package", NewErrRequired("package"))
+	}
+	if t.Name != "" && !unicode.IsUpper([]rune(t.Name)[0]) {
+		v.add(path+".name", NewErrUnexportedExternalType(t.Name))
+	}
+	if !slices.Contains([]PrimitiveType{
+		PrimitiveTypeString,
+		PrimitiveTypeInt,
+		PrimitiveTypeFloat,
+		PrimitiveTypeBool,
+	}, t.GetRepresentation()) {
+		v.add(path+".representation", NewErrUnsupportedRepresentation(string(t.Representation)))
+	}
+	if (t.ToRepresentation == "") != (t.FromRepresentation == "") {
+		v.add(path, NewErrIncompleteConverters(t.Name))
+	}
+}
+
+func (v *domainValidator) validateRelation(path string, r *Relation) {
+	if r.Source == nil {
+		v.add(path+".source", NewErrRequired("source"))
+	} else if !v.models[r.Source] {
+		v.add(path+".source", NewErrModelNotFound(r.Source.Name))
+	}
+	if r.Target == nil {
+		v.add(path+".target", NewErrRequired("target"))
+	} else if !v.models[r.Target] {
+		v.add(path+".target", NewErrModelNotFound(r.Target.Name))
+	}
+
+	if !slices.Contains([]RelationType{
+		RelationTypeOneToOne,
+		RelationTypeOneToMany,
+		RelationTypeManyToOne,
+		RelationTypeManyToMany,
+		RelationTypeBelongsTo,
+		RelationTypeSubresourcesOf,
+	}, r.Type) {
+		v.add(path+".type", NewErrUnknownRelationType(string(r.Type)))
+	}
+}
+
+func (v *domainValidator) validateRepository(path string, r *Repository) {
+	if r.On == nil {
+		v.add(path+".on", NewErrRequired("on"))
+	} else if !v.models[r.On] {
+		v.add(path+".on", NewErrModelNotFound(r.On.Name))
+	} else if v.repositories[r.On] {
+		v.add(path, NewErrDuplicateDefinition(r.On.Name))
+	} else {
+		v.repositories[r.On] = true
+	}
+
+	for _, m := range r.Methods {
+		methodPath := fmt.Sprintf("%s.methods[%s]", path, m.Name)
+		if m.Name == "" {
+			v.add(methodPath+".name", NewErrRequired("name"))
+		}
+		v.validateParams(methodPath+".params", m.Params)
+		for i, t := range m.Results {
+			v.validateType(fmt.Sprintf("%s.results[%d]", methodPath, i), t)
+		}
+	}
+}
+
+func (v *domainValidator) validateCRUD(path string, c *CRUD) {
+	if c.On == nil {
+		v.add(path+".on", NewErrRequired("on"))
+		return
+	}
+	if !v.models[c.On] {
+		v.add(path+".on", NewErrModelNotFound(c.On.Name))
+		return
+	}
+	if !v.repositories[c.On] {
+		v.add(path+".on", NewErrMissingRepository(c.On.Name))
+	}
+
+	if c.GetActive.Active && !v.isActivable(c.On, map[*Model]bool{}) {
+		v.add(path+".getActive", NewErrModelNotActivable(c.On.Name))
+	}
+	if c.ListActive.Active && !v.isActivable(c.On, map[*Model]bool{}) {
+		v.add(path+".listActive", NewErrModelNotActivable(c.On.Name))
+	}
+
+	for _, rc := range c.RelationCRUDs {
+		if rc.Relation == nil {
+			v.add(path+".relationCruds", NewErrRequired("relation"))
+			continue
+		}
+		relationPath := fmt.Sprintf("%s.relationCruds[%s]", path, relationName(rc.Relation))
+		if !slices.Contains(v.domain.Relations, rc.Relation) {
+			v.add(relationPath, NewErrRelationNotFound(modelName(rc.Relation.Source), modelName(rc.Relation.Target)))
+		} else if rc.Relation.Source != c.On && rc.Relation.Target != c.On {
+			v.add(relationPath, NewErrRelationNotInvolvingModel(modelName(rc.Relation.Source), modelName(rc.Relation.Target), c.On.Name))
+		}
+	}
+}
+
+func (v *domainValidator) validateParams(path string, params []*Param) {
+	names := map[string]bool{}
+	for _, p := range params {
+		paramPath := fmt.Sprintf("%s[%s]", path, p.Name)
+		if p.Name == "" {
+			v.add(paramPath+".name", NewErrRequired("name"))
+		} else if names[p.Name] {
+			v.add(paramPath, NewErrDuplicateDefinition(p.Name))
+		}
+		names[p.Name] = true
+
+		v.validateType(paramPath+".type", p.Type)
+		v.validateValidations(paramPath, nil, p.Type, p.Validations)
+		v.validateOptional(paramPath, p.Name, p.Optional, p.Validations)
+		v.validateDecimal(paramPath, p.Type, p.Precision, p.Scale)
+	}
+}
+
+func (v *domainValidator) validateType(path string, t Type) {
+	switch t := t.(type) {
+	case nil:
+		v.add(path, NewErrRequired("type"))
+	case PrimitiveType:
+		if !slices.Contains([]PrimitiveType{
+			PrimitiveTypeInt,
+			PrimitiveTypeFloat,
+			PrimitiveTypeString,
+			PrimitiveTypeBool,
+			PrimitiveTypeByte,
+			PrimitiveTypeBytes,
+			PrimitiveTypeDate,
+			PrimitiveTypeDateTime,
+			PrimitiveTypeTime,
+			PrimitiveTypeFile,
+			PrimitiveTypeDecimal,
+			PrimitiveTypeMoney,
+			PrimitiveTypeJSON,
+		}, t) {
+			v.add(path, NewErrUnknownType(string(t)))
+		}
+	case *Array:
+		v.validateType(path, t.Type)
+	case *Map:
+		// JSON object keys are strings, enums and ints are converted by encoding/json
+		switch key := t.Key.(type) {
+		case nil:
+			v.add(path, NewErrRequired("key"))
+		case *Enum:
+			v.validateType(path, key)
+		default:
+			if key != PrimitiveTypeString && key != PrimitiveTypeInt {
+				v.add(path, NewErrUnsupportedMapType(t.Key.GetType()))
+			}
+		}
+		switch t.Value.(type) {
+		case *Model:
+			v.add(path, NewErrUnsupportedMapType(t.Value.GetType()))
+		default:
+			if t.Value == PrimitiveTypeFile {
+				v.add(path, NewErrUnsupportedMapType(t.Value.GetType()))
+			} else {
+				v.validateType(path, t.Value)
+			}
+		}
+	case *Model:
+		if !v.models[t] {
+			v.add(path, NewErrModelNotFound(t.Name))
+		}
+	case *Enum:
+		if !v.enums[t] {
+			v.add(path, NewErrUnknownType(t.Name))
+		}
+	case *ValueObject:
+		if !v.valueObjects[t] {
+			v.add(path, NewErrUnknownType(t.Name))
+		}
+	case *ExternalType:
+		if !v.external[t] {
+			v.add(path, NewErrUnknownType(t.Name))
+		}
+	default:
+		v.add(path, NewErrUnknownType(fmt.Sprintf("%T", t)))
+	}
+}
+
+// validateValidations checks the validation rules of a field of type t; on is nil for params
+func (v *domainValidator) validateValidations(path string, on *Model, t Type, validations []*Validation) {
+	for _, validation := range validations {
+		validationPath := fmt.Sprintf("%s.validations[%s]", path, validation.Rule)
+		switch validation.Rule {
+		case ValidationRuleRequired, ValidationRuleEmail, ValidationRuleUUID, ValidationRuleHexColor,
+			ValidationRuleUnique, ValidationRuleURL, ValidationRuleIP, ValidationRuleAlphaNum:
+		case ValidationRuleMIMETypes:
+			if t != PrimitiveTypeFile {
+				v.add(validationPath, NewErrFileValidation(string(validation.Rule)))
+			} else if values, ok := validation.GetValues(); !ok {
+				v.add(validationPath, NewErrValidationValueExpectedType(string(validation.Rule), "list"))
+			} else {
+				for _, value := range values {
+					if !strings.Contains(value, "/") {
+						v.add(validationPath, NewErrInvalidMIMEType(value))
+					}
+				}
+			}
+		case ValidationRuleMaxSize:
+			if t != PrimitiveTypeFile {
+				v.add(validationPath, NewErrFileValidation(string(validation.Rule)))
+			} else if _, ok := validation.GetSize(); !ok {
+				v.add(validationPath, NewErrValidationValueExpectedType(string(validation.Rule), "size"))
+			}
+		case ValidationRuleGT, ValidationRuleGTE, ValidationRuleLT, ValidationRuleLTE:
+			if _, ok := validation.GetNumber(); !ok {
+				v.add(validationPath, NewErrValidationValueExpectedType(string(validation.Rule), "number"))
+			}
+		case ValidationRuleMinLength, ValidationRuleMaxLength, ValidationRuleLen:
+			if _, ok := validation.GetLength(); !ok {
+				v.add(validationPath, NewErrValidationValueExpectedType(string(validation.Rule), "non negative integer"))
+			}
+		case ValidationRulePattern:
+			pattern, ok := validation.Value.(string)
+			if !ok {
+				v.add(validationPath, NewErrValidationValueExpectedType(string(validation.Rule), "string"))
+			} else if _, err := regexp.Compile(pattern); err != nil {
+				v.add(validationPath, NewErrInvalidPattern(pattern, err))
+			} else {
+				v.validateTagValue(validationPath, validation.Rule, pattern)
+			}
+		case ValidationRuleOneOf:
+			if values, ok := validation.GetValues(); !ok {
+				v.add(validationPath, NewErrValidationValueExpectedType(string(validation.Rule), "list"))
+			} else {
+				for _, value := range values {
+					v.validateTagValue(validationPath, validation.Rule, value)
+				}
+			}
+		case ValidationRuleStartsWith, ValidationRuleEndsWith:
+			if s, ok := validation.Value.(string); !ok || s == "" {
+				v.add(validationPath, NewErrValidationValueExpectedType(string(validation.Rule), "string"))
+			} else {
+				v.validateTagValue(validationPath, validation.Rule, s)
+			}
+		case ValidationRuleUniqueIn:
+			in, ok := validation.Value.(*Model)
+			if !ok {
+				v.add(validationPath, NewErrValidationValueExpectedType(string(validation.Rule), "*coredomaindefinition.Model"))
+			} else if !v.models[in] {
+				v.add(validationPath, NewErrModelNotFound(in.Name))
+			} else if on != nil && !v.hasSingleRelation(on, in) {
+				v.add(validationPath, NewErrUnrelatedModel(on.Name, in.Name))
+			}
+		default:
+			v.add(validationPath, NewErrUnknownValidationRule(string(validation.Rule)))
+		}
+	}
+}
+
+// validateTagValue checks a validation value written in the validate tag of the generated structs
+func (v *domainValidator) validateTagValue(path string, rule ValidationRule, value string) {
+	if strings.Contains(value, "`") {
+		v.add(path, NewErrBacktickInTag(string(rule), value))
+	}
+}
+
+func (v *domainValidator) validateOptional(path string, name string, optional bool, validations []*Validation) {
+	if !optional {
+		return
+	}
+	for _, validation := range validations {
+		if validation.Rule == ValidationRuleRequired {
+			v.add(path+".optional", NewErrOptionalRequired(name))
+		}
+	}
+}
+
+func (v *domainValidator) validateIDStrategy(path string, strategy IDStrategy) {
+	if strategy != "" && !slices.Contains([]IDStrategy{
+		IDStrategyUUID,
+		IDStrategyUUIDv7,
+		IDStrategyULID,
+		IDStrategyAutoIncrement,
+	}, strategy) {
+		v.add(path, NewErrUnknownIDStrategy(string(strategy)))
+	}
+}
+
+// validateFieldsValidations checks the validations on several fields reference fields of types they can check
+func (v *domainValidator) validateFieldsValidations(path string, fields []*Field, validations []*FieldsValidation) {
+	for _, validation := range validations {
+		validationPath := fmt.Sprintf("%s.validations[%s]", path, validation.Rule)
+		if !validation.IsComparison() && validation.Rule != FieldsValidationRuleAnyRequired {
+			v.add(validationPath, NewErrUnknownValidationRule(string(validation.Rule)))
+			continue
+		}
+		if validation.IsComparison() && len(validation.Fields) != 2 {
+			v.add(validationPath+".fields", NewErrFieldsValidationCount(string(validation.Rule), "2"))
+			continue
+		}
+		if len(validation.Fields) < 2 {
+			v.add(validationPath+".fields", NewErrFieldsValidationCount(string(validation.Rule), "at least 2"))
+			continue
+		}
+
+		found := []*Field{}
+		for _, name := range validation.Fields {
+			i := slices.IndexFunc(fields, func(f *Field) bool { return f.Name == name })
+			if i < 0 {
+				v.add(validationPath+".fields", NewErrFieldNotFound(name))
+				continue
+			}
+			if fields[i].Type == nil {
+				continue
+			}
+			if !isFieldsValidationSupported(validation, fields[i]) {
+				v.add(validationPath+".fields", NewErrUnsupportedFieldsValidation(string(validation.Rule), name, fields[i].Type.GetType()))
+				continue
+			}
+			found = append(found, fields[i])
+		}
+		if validation.IsComparison() && len(found) == 2 && found[0].Type != found[1].Type {
+			v.add(validationPath+".fields", NewErrMismatchedFieldTypes(found[0].Name, found[1].Name))
+		}
+	}
+}
+
+// isFieldsValidationSupported tells the generated code can check the rule on f
+func isFieldsValidationSupported(validation *FieldsValidation, f *Field) bool {
+	switch t := f.Type.(type) {
+	case PrimitiveType:
+		switch t {
+		case PrimitiveTypeInt, PrimitiveTypeFloat, PrimitiveTypeByte, PrimitiveTypeString,
+			PrimitiveTypeDate, PrimitiveTypeTime, PrimitiveTypeDateTime, PrimitiveTypeDecimal:
+			return true
+		case PrimitiveTypeBool:
+			return !validation.IsOrdering()
+		case PrimitiveTypeBytes, PrimitiveTypeFile, PrimitiveTypeJSON:
+			return !validation.IsComparison()
+		}
+	case *Enum:
+		return !validation.IsOrdering()
+	case *Array, *Map:
+		return !validation.IsComparison()
+	}
+	// other types are only checked to be set, through their pointer
+	return validation.Rule == FieldsValidationRuleAnyRequired && f.Optional
+}
+
+var decimalPattern = regexp.MustCompile(DECIMAL_PATTERN)
+
+// validateDefault checks the default of a model field is a literal of its type or one of its generators
+func (v *domainValidator) validateDefault(path string, f *Field) {
+	if f.Default == nil || f.Type == nil {
+		return
+	}
+	path += ".default"
+	for _, validation := range f.Validations {
+		if validation.Rule == ValidationRuleRequired {
+			v.add(path, NewErrRequiredDefault(f.Name))
+		}
+	}
+
+	valid := false
+	switch value := f.Default.(type) {
+	case DefaultGenerator:
+		switch value {
+		case DefaultGeneratorNow:
+			valid = f.Type == PrimitiveTypeDateTime || f.Type == PrimitiveTypeDate || f.Type == PrimitiveTypeTime
+		case DefaultGeneratorUUID:
+			valid = f.Type == PrimitiveTypeString
+		}
+	case string:
+		switch t := f.Type.(type) {
+		case *Enum:
+			valid = slices.Contains(t.Values, value)
+		case PrimitiveType:
+			switch t {
+			case PrimitiveTypeString:
+				valid = true
+			case PrimitiveTypeDate:
+				_, err := time.Parse(time.DateOnly, value)
+				valid = err == nil
+			case PrimitiveTypeTime:
+				_, err := time.Parse(time.TimeOnly, value)
+				valid = err == nil
+			case PrimitiveTypeDecimal:
+				valid = decimalPattern.MatchString(value)
+			}
+		}
+	case bool:
+		valid = f.Type == PrimitiveTypeBool
+		if valid && value && !f.Optional {
+			v.add(path, NewErrAmbiguousDefault(value, f.Name))
+		}
+	case int:
+		valid = f.Type == PrimitiveTypeInt || f.Type == PrimitiveTypeFloat || f.Type == PrimitiveTypeDecimal
+	case float64:
+		// JSON numbers are float64
+		valid = f.Type == PrimitiveTypeFloat || f.Type == PrimitiveTypeDecimal || (f.Type == PrimitiveTypeInt && value == math.Trunc(value))
+	}
+	if !valid {
+		v.add(path, NewErrInvalidDefault(f.Default, f.Type.GetType()))
+	}
+}
+
+// validateDecimal checks precision and scale are only set on decimals and fit in a numeric column
+func (v *domainValidator) validateDecimal(path string, t Type, precision int, scale int) {
+	if precision == 0 && scale == 0 {
+		return
+	}
+	if t != PrimitiveTypeDecimal {
+		v.add(path+".precision", NewErrUnexpectedPrecision(t.GetType()))
+		return
+	}
+
+	if precision == 0 {
+		precision = DEFAULT_DECIMAL_PRECISION
+	}
+	if precision < 0 || precision > MAX_DECIMAL_PRECISION || scale < 0 || scale > precision {
+		v.add(path+".precision", NewErrInvalidPrecision(precision, scale))
+	}
+}
+
+// hasSingleRelation reports whether m holds a single reference to the model to
+func (v *domainValidator) hasSingleRelation(m *Model, to *Model) bool {
+	for _, r := range v.domain.Relations {
+		if r.Source == m && r.Target == to && !slices.Contains([]RelationType{
+			RelationTypeManyToMany,
+			RelationTypeOneToMany,
+		}, r.Type) {
+			return true
+		}
+		if r.Target == m && r.Source == to && slices.Contains([]RelationType{
+			RelationTypeOneToOne,
+			RelationTypeOneToMany,
+		}, r.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// isActivable reports whether m or one of the models it depends on is activable
+func (v *domainValidator) isActivable(m *Model, visited map[*Model]bool) bool {
+	if m.Activable {
+		return true
+	}
+	visited[m] = true
+
+	for _, r := range v.domain.Relations {
+		if r.Source != m || r.Target == nil || visited[r.Target] {
+			continue
+		}
+		if slices.Contains([]RelationType{RelationTypeBelongsTo, RelationTypeSubresourcesOf}, r.Type) && v.isActivable(r.Target, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+func modelName(m *Model) string {
+	if m == nil {
+		return ""
+	}
+	return m.Name
+}
+
+func relationName(r *Relation) string {
+	return modelName(r.Source) + "->" + modelName(r.Target)
+}
+
+// Validate checks every domain of the platform and that all roles used by CRUDs and usecases are declared by the platform.
+// Paths of domain problems are prefixed by platform.domains[name].
+func (platform *Platform) Validate() error {
+	errs := DefinitionErrors{}
+	add := func(path string, err error) {
+		errs = append(errs, &DefinitionError{Path: path, Err: err})
+	}
+
+	if platform.Name == "" {
+		add("platform.name", NewErrRequired("name"))
+	}
+
+	roles := map[string]bool{}
+	// roles by the go constant generated for them in the roles package
+	constants := map[string]string{}
+	for _, role := range platform.Roles {
+		rolePath := fmt.Sprintf("platform.roles[%s]", role)
+		if role == "" {
+			add(rolePath, NewErrRequired("role"))
+			continue
+		}
+		if roles[role] {
+			add(rolePath, NewErrDuplicateDefinition(role))
+			continue
+		}
+		roles[role] = true
+
+		constant := GetRoleConstName(role)
+		if constant == ROLES_ALL_NAME {
+			add(rolePath, NewErrReservedName(role, ROLES_ALL_NAME))
+		} else if other, ok := constants[constant]; ok {
+			add(rolePath, NewErrIdentifierCollision(role, other, constant))
+		}
+		constants[constant] = role
+	}
+	checkRoles := func(path string, used []string) {
+		for _, role := range used {
+			if !roles[role] {
+				add(fmt.Sprintf("%s.roles[%s]", path, role), NewErrUnknownRole(role))
+			}
+		}
+	}
+
+	names := map[string]bool{}
+	for _, domain := range platform.Domains {
+		path := fmt.Sprintf("platform.domains[%s]", domain.Name)
+		if names[domain.Name] {
+			add(path, NewErrDuplicateDefinition(domain.Name))
+		} else if domain.Name == ROLES_FOLDER {
+			// domains are generated in folders next to the roles one
+			add(path+".name", NewErrReservedName(domain.Name, ROLES_FOLDER))
+		}
+		names[domain.Name] = true
+
+		if err := domain.Validate(); err != nil {
+			for _, e := range err.(DefinitionErrors) {
+				add(path+strings.TrimPrefix(e.Path, "domain"), e.Err)
+			}
+		}
+
+		for _, c := range domain.CRUDs {
+			crudPath := fmt.Sprintf("%s.cruds[%s]", path, modelName(c.On))
+			checkRoles(crudPath+".create", c.Create.Roles)
+			checkRoles(crudPath+".get", c.Get.Roles)
+			checkRoles(crudPath+".getActive", c.GetActive.Roles)
+			checkRoles(crudPath+".list", c.List.Roles)
+			checkRoles(crudPath+".listActive", c.ListActive.Roles)
+			checkRoles(crudPath+".update", c.Update.Roles)
+			checkRoles(crudPath+".delete", c.Delete.Roles)
+			for _, rc := range c.RelationCRUDs {
+				if rc.Relation == nil {
+					continue
+				}
+				relationPath := fmt.Sprintf("%s.relationCruds[%s]", crudPath, relationName(rc.Relation))
+				checkRoles(relationPath, rc.Roles)
+				checkRoles(relationPath+".add", rc.Add.Roles)
+				checkRoles(relationPath+".remove", rc.Remove.Roles)
+				checkRoles(relationPath+".list", rc.List.Roles)
+			}
+		}
+
+		for _, u := range domain.Usecases {
+			checkRoles(fmt.Sprintf("%s.usecases[%s]", path, u.Name), u.Roles)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,364 @@
+package coredomaindefinition
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// validDomain returns a domain without problem, mutated by the test cases
+func validDomain() *Domain {
+	user := NewModel("user")
+	user.Fields = []*Field{
+		{Name: "email", Type: PrimitiveTypeString, Validations: []*Validation{{Rule: ValidationRuleRequired}, {Rule: ValidationRuleEmail}}},
+		{Name: "startDate", Type: PrimitiveTypeDate},
+		{Name: "endDate", Type: PrimitiveTypeDate, Optional: true},
+	}
+	shop := NewModel("shop")
+	shop.Fields = []*Field{{Name: "name", Type: PrimitiveTypeString}}
+	other := NewModel("other")
+	status := &Enum{Name: "status", Values: []string{"open", "closed"}}
+	shop.Fields = append(shop.Fields, &Field{Name: "status", Type: status})
+
+	relation := &Relation{Source: user, Target: shop, Type: RelationTypeManyToOne}
+	return &Domain{
+		Name:          "shop",
+		Configuration: &DomainConfiguration{Package: "github.com/acme/shop"},
+		Models:        []*Model{user, shop, other},
+		Enums:         []*Enum{status},
+		Relations:     []*Relation{relation},
+		Repositories:  []*Repository{{On: user}, {On: shop}},
+		CRUDs: []*CRUD{{
+			On:            user,
+			Create:        CRUDAction{Active: true},
+			RelationCRUDs: []*RelationCRUD{{Relation: relation, Add: CRUDAction{Active: true}}},
+		}},
+		Usecases: []*Usecase{{Name: "login", Args: []*Param{{Name: "code", Type: PrimitiveTypeString}}}},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(d *Domain)
+		// expected lines of DefinitionErrors, in order
+		expected []string
+	}{
+		{
+			name:   "valid",
+			mutate: func(d *Domain) {},
+		},
+		{
+			name: "missing name and package",
+			mutate: func(d *Domain) {
+				d.Name = ""
+				d.Configuration = nil
+			},
+			expected: []string{
+				"domain.name: name is required",
+				"domain.configuration.package: package is required",
+			},
+		},
+		{
+			name:     "unknown dialect",
+			mutate:   func(d *Domain) { d.Configuration.Dialect = "oracle" },
+			expected: []string{"domain.configuration.dialect: unknown dialect 'oracle', expected postgres, mysql or sqlite"},
+		},
+		{
+			name:     "duplicate model",
+			mutate:   func(d *Domain) { d.Models = append(d.Models, NewModel("shop")) },
+			expected: []string{"domain.models[shop]: 'shop' is defined more than once"},
+		},
+		{
+			name:     "enum named as a model",
+			mutate:   func(d *Domain) { d.Enums[0].Name = "user" },
+			expected: []string{"domain.enums[user]: 'user' is defined more than once"},
+		},
+		{
+			name:   "invalid enum values",
+			mutate: func(d *Domain) { d.Enums[0].Values = []string{"open", "open", "a b"} },
+			expected: []string{
+				"domain.enums[status].values[open]: 'open' is defined more than once",
+				"domain.enums[status].values[a b]: invalid enum value 'a b'",
+			},
+		},
+		{
+			name:   "enum values generating the same constant",
+			mutate: func(d *Domain) { d.Enums[0].Values = []string{"in-progress", "in_progress", "Open", "open"} },
+			expected: []string{
+				"domain.enums[status].values[in_progress]: 'in_progress' and 'in-progress' both generate 'StatusInProgress'",
+				"domain.enums[status].values[open]: 'open' and 'Open' both generate 'StatusOpen'",
+			},
+		},
+		{
+			name:     "enum values generating the same javascript key",
+			mutate:   func(d *Domain) { d.Enums[0].Values = []string{"inProgress", "inprogress"} },
+			expected: []string{"domain.enums[status].values[inprogress]: 'inprogress' and 'inProgress' both generate 'INPROGRESS'"},
+		},
+		{
+			name:     "enum value generating a type name",
+			mutate:   func(d *Domain) { d.Models[2].Name = "statusOpen" },
+			expected: []string{"domain.enums[status].values[open]: 'open' and 'statusOpen' both generate 'StatusOpen'"},
+		},
+		{
+			name: "enum values generating the constant of another enum",
+			mutate: func(d *Domain) {
+				d.Enums[0].Values = append(d.Enums[0].Values, "in-progress")
+				d.Enums = append(d.Enums, &Enum{Name: "statusIn", Values: []string{"progress"}})
+			},
+			expected: []string{"domain.enums[statusIn].values[progress]: 'progress' and 'status.in-progress' both generate 'StatusInProgress'"},
+		},
+		{
+			name:     "unknown relation type",
+			mutate:   func(d *Domain) { d.Relations[0].Type = "parentOf" },
+			expected: []string{"domain.relations[user->shop].type: unknown relation type 'parentOf'"},
+		},
+		{
+			name:     "relation to a model of another domain",
+			mutate:   func(d *Domain) { d.Relations[0].Target = NewModel("order") },
+			expected: []string{"domain.relations[user->order].target: model 'order' not found"},
+		},
+		{
+			name:     "unknown field type",
+			mutate:   func(d *Domain) { d.Models[0].Fields[0].Type = PrimitiveType("uint") },
+			expected: []string{"domain.models[user].fields[email].type: unknown type ‘uint’"},
+		},
+		{
+			name: "duplicate field",
+			mutate: func(d *Domain) {
+				d.Models[1].Fields = append(d.Models[1].Fields, &Field{Name: "name", Type: PrimitiveTypeString})
+			},
+			expected: []string{"domain.models[shop].fields[name]: 'name' is defined more than once"},
+		},
+		{
+			name:     "optional and required",
+			mutate:   func(d *Domain) { d.Models[0].Fields[0].Optional = true },
+			expected: []string{"domain.models[user].fields[email].optional: 'email' can not be both optional and required"},
+		},
+		{
+			name:     "unknown validation rule",
+			mutate:   func(d *Domain) { d.Models[0].Fields[0].Validations[1].Rule = "phone" },
+			expected: []string{"domain.models[user].fields[email].validations[phone]: unknown validation rule 'phone'"},
+		},
+		{
+			name: "invalid pattern",
+			mutate: func(d *Domain) {
+				d.Models[1].Fields[0].Validations = []*Validation{{Rule: ValidationRulePattern, Value: "[a-z"}}
+			},
+			expected: []string{"domain.models[shop].fields[name].validations[pattern]: invalid pattern '[a-z'"},
+		},
+		{
+			name: "backtick in validate tags",
+			mutate: func(d *Domain) {
+				d.Models[1].Fields[0].Validations = []*Validation{
+					{Rule: ValidationRulePattern, Value: "[a-z`]+"},
+					{Rule: ValidationRuleOneOf, Value: []string{"a", "`b`"}},
+					{Rule: ValidationRuleStartsWith, Value: "`"},
+				}
+			},
+			expected: []string{
+				"domain.models[shop].fields[name].validations[pattern]: value '[a-z`]+' of validation pattern can not contain a backtick",
+				"domain.models[shop].fields[name].validations[oneof]: value '`b`' of validation oneof can not contain a backtick",
+				"domain.models[shop].fields[name].validations[startsWith]: value '`' of validation startsWith can not contain a backtick",
+			},
+		},
+		{
+			name: "uniqueIn without relation",
+			mutate: func(d *Domain) {
+				d.Models[1].Fields[0].Validations = []*Validation{{Rule: ValidationRuleUniqueIn, Value: d.Models[2]}}
+			},
+			expected: []string{"domain.models[shop].fields[name].validations[uniqueIn]: model 'shop' has no single relation to 'other'"},
+		},
+		{
+			name:     "precision on a string",
+			mutate:   func(d *Domain) { d.Models[1].Fields[0].Precision = 10 },
+			expected: []string{"domain.models[shop].fields[name]"},
+		},
+		{
+			name: "decimal defaults",
+			mutate: func(d *Domain) {
+				d.Models[1].Fields = append(d.Models[1].Fields,
+					&Field{Name: "price", Type: PrimitiveTypeDecimal, Default: "-12.50"},
+					&Field{Name: "exponent", Type: PrimitiveTypeDecimal, Default: "1e3"},
+					&Field{Name: "hexadecimal", Type: PrimitiveTypeDecimal, Default: "0x1p-2"},
+					&Field{Name: "underscore", Type: PrimitiveTypeDecimal, Default: "1_000"},
+					&Field{Name: "fraction", Type: PrimitiveTypeDecimal, Default: "1/3"},
+				)
+			},
+			expected: []string{
+				"domain.models[shop].fields[exponent].default: ",
+				"domain.models[shop].fields[hexadecimal].default: ",
+				"domain.models[shop].fields[underscore].default: ",
+				"domain.models[shop].fields[fraction].default: ",
+			},
+		},
+		{
+			name: "fields validation on an unknown field",
+			mutate: func(d *Domain) {
+				d.Models[0].Validations = []*FieldsValidation{{Rule: FieldsValidationRuleGTField, Fields: []string{"endDate", "beginDate"}}}
+			},
+			expected: []string{"domain.models[user].validations[gtField].fields: field 'beginDate' not found"},
+		},
+		{
+			name: "fields validation of different types",
+			mutate: func(d *Domain) {
+				d.Models[0].Validations = []*FieldsValidation{{Rule: FieldsValidationRuleGTField, Fields: []string{"endDate", "email"}}}
+			},
+			expected: []string{"domain.models[user].validations[gtField].fields: 'endDate' and 'email' can not be compared"},
+		},
+		{
+			name:     "crud without repository",
+			mutate:   func(d *Domain) { d.Repositories = d.Repositories[1:] },
+			expected: []string{"domain.cruds[user].on: model 'user' has no repository"},
+		},
+		{
+			name:     "list active on a model which is not activable",
+			mutate:   func(d *Domain) { d.CRUDs[0].ListActive.Active = true },
+			expected: []string{"domain.cruds[user].listActive: model 'user' and his dependency relations is not activable"},
+		},
+		{
+			name: "relation crud not involving the model",
+			mutate: func(d *Domain) {
+				d.Repositories = append(d.Repositories, &Repository{On: d.Models[2]})
+				d.CRUDs[0].On = d.Models[2]
+			},
+			expected: []string{"domain.cruds[other].relationCruds[user->shop]: relation between 'user' and 'shop' does not involve model 'other'"},
+		},
+		{
+			name: "relation crud on an undeclared relation",
+			mutate: func(d *Domain) {
+				d.CRUDs[0].RelationCRUDs[0].Relation = &Relation{Source: d.Models[0], Target: d.Models[2], Type: RelationTypeManyToOne}
+			},
+			expected: []string{"domain.cruds[user].relationCruds[user->other]: relation between 'user' and 'other' not found"},
+		},
+		{
+			name: "duplicate usecase and args",
+			mutate: func(d *Domain) {
+				d.Usecases = append(d.Usecases, &Usecase{Name: "login", Args: []*Param{{Name: "a", Type: PrimitiveTypeInt}, {Name: "a", Type: PrimitiveTypeInt}}})
+			},
+			expected: []string{
+				"domain.usecases[login]: 'login' is defined more than once",
+				"domain.usecases[login].args[a]: 'a' is defined more than once",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			domain := validDomain()
+			test.mutate(domain)
+
+			err := domain.Validate()
+			if len(test.expected) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+				return
+			}
+
+			var errs DefinitionErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("expected DefinitionErrors, got %v", err)
+			}
+			if len(errs) != len(test.expected) {
+				t.Fatalf("expected %d errors, got %d:\n%v", len(test.expected), len(errs), err)
+			}
+			for i, expected := range test.expected {
+				if !strings.HasPrefix(errs[i].Error(), expected) {
+					t.Errorf("expected error %d to start with %q, got %q", i, expected, errs[i].Error())
+				}
+			}
+		})
+	}
+}
+
+func TestDefinitionErrorsAreAggregated(t *testing.T) {
+	domain := validDomain()
+	domain.Name = ""
+	domain.Relations[0].Type = "parentOf"
+
+	err := domain.Validate()
+	expected := "domain.name: name is required\ndomain.relations[user->shop].type: unknown relation type 'parentOf'"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected %q, got %v", expected, err)
+	}
+
+	var definitionError *DefinitionError
+	if !errors.As(err.(DefinitionErrors)[0], &definitionError) || definitionError.Path != "domain.name" {
+		t.Fatalf("expected the path of the first error, got %v", definitionError)
+	}
+}
+
+func TestPlatformValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutate   func(p *Platform)
+		expected []string
+	}{
+		{
+			name:   "valid",
+			mutate: func(p *Platform) {},
+		},
+		{
+			name:     "duplicate role",
+			mutate:   func(p *Platform) { p.Roles = append(p.Roles, "admin") },
+			expected: []string{"platform.roles[admin]: 'admin' is defined more than once"},
+		},
+		{
+			name:     "role colliding with the list of roles",
+			mutate:   func(p *Platform) { p.Roles = append(p.Roles, "all") },
+			expected: []string{"platform.roles[all]: 'all' collides with the generated 'All'"},
+		},
+		{
+			name:     "roles generating the same constant",
+			mutate:   func(p *Platform) { p.Roles = append(p.Roles, "super_admin") },
+			expected: []string{"platform.roles[super_admin]: 'super_admin' and 'super-admin' both generate 'SuperAdmin'"},
+		},
+		{
+			name:     "empty role",
+			mutate:   func(p *Platform) { p.Roles = append(p.Roles, "") },
+			expected: []string{"platform.roles[]: role is required"},
+		},
+		{
+			name:     "unknown role",
+			mutate:   func(p *Platform) { p.Domains[0].Usecases[0].Roles = []string{"guest"} },
+			expected: []string{"platform.domains[shop].usecases[login].roles[guest]: role 'guest' is not declared by the platform"},
+		},
+		{
+			name:     "domain named as the roles folder",
+			mutate:   func(p *Platform) { p.Domains[0].Name = "roles" },
+			expected: []string{"platform.domains[roles].name: 'roles' collides with the generated 'roles'"},
+		},
+		{
+			name:     "domain problems are prefixed by the domain",
+			mutate:   func(p *Platform) { p.Domains[0].Relations[0].Type = "parentOf" },
+			expected: []string{"platform.domains[shop].relations[user->shop].type: unknown relation type 'parentOf'"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			platform := &Platform{Name: "acme", Domains: []*Domain{validDomain()}, Roles: []string{"admin", "super-admin"}}
+			test.mutate(platform)
+
+			err := platform.Validate()
+			if len(test.expected) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+				return
+			}
+
+			var errs DefinitionErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("expected DefinitionErrors, got %v", err)
+			}
+			if len(errs) != len(test.expected) {
+				t.Fatalf("expected %d errors, got %d:\n%v", len(test.expected), len(errs), err)
+			}
+			for i, expected := range test.expected {
+				if errs[i].Error() != expected {
+					t.Errorf("expected error %d %q, got %q", i, expected, errs[i].Error())
+				}
+			}
+		})
+	}
+}
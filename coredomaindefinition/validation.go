@@ -1,22 +1,123 @@
 package coredomaindefinition
 
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
 type ValidationRule string
 
 const (
-	ValidationRuleRequired  ValidationRule = "required"
-	ValidationRuleEmail     ValidationRule = "email"
-	ValidationRuleGT        ValidationRule = "gt"
-	ValidationRuleGTE       ValidationRule = "gte"
-	ValidationRuleLT        ValidationRule = "lt"
-	ValidationRuleLTE       ValidationRule = "lte"
-	ValidationRuleUUID      ValidationRule = "uuid"
-	ValidationRuleHexColor  ValidationRule = "hexcolor"
-	ValidationRuleUnique    ValidationRule = "unique"
-	ValidationRuleUniqueIn  ValidationRule = "uniqueIn"
+	ValidationRuleRequired ValidationRule = "required"
+	ValidationRuleEmail    ValidationRule = "email"
+	ValidationRuleGT       ValidationRule = "gt"
+	ValidationRuleGTE      ValidationRule = "gte"
+	ValidationRuleLT       ValidationRule = "lt"
+	ValidationRuleLTE      ValidationRule = "lte"
+	ValidationRuleUUID     ValidationRule = "uuid"
+	ValidationRuleHexColor ValidationRule = "hexcolor"
+	ValidationRuleUnique   ValidationRule = "unique"
+	ValidationRuleUniqueIn ValidationRule = "uniqueIn"
+	// MIMETypes are the accepted types of a file, e.g. application/pdf or image/*
 	ValidationRuleMIMETypes ValidationRule = "mimetypes"
+	// MaxSize is the largest file accepted, in bytes or with a KB, MB or GB unit, e.g. 50MB
+	ValidationRuleMaxSize ValidationRule = "maxSize"
+	// length of strings and number of elements of arrays and maps
+	ValidationRuleMinLength ValidationRule = "minLength"
+	ValidationRuleMaxLength ValidationRule = "maxLength"
+	ValidationRuleLen       ValidationRule = "len"
+	// Pattern is a go regular expression the whole value must match
+	ValidationRulePattern    ValidationRule = "pattern"
+	ValidationRuleOneOf      ValidationRule = "oneof"
+	ValidationRuleURL        ValidationRule = "url"
+	ValidationRuleIP         ValidationRule = "ip"
+	ValidationRuleAlphaNum   ValidationRule = "alphanum"
+	ValidationRuleStartsWith ValidationRule = "startsWith"
+	ValidationRuleEndsWith   ValidationRule = "endsWith"
 )
 
+// DEFAULT_FILE_MIME_TYPES and DEFAULT_FILE_MAX_SIZE are the limits of files without mimetypes or maxSize rules
+var DEFAULT_FILE_MIME_TYPES = []string{"image/png", "image/jpeg", "image/webp"}
+
+const DEFAULT_FILE_MAX_SIZE = 10 << 20
+
+// sizeUnits are the multiples of bytes of a maxSize rule
+var sizeUnits = []struct {
+	suffix string
+	bytes  int64
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
 type Validation struct {
 	Rule  ValidationRule
 	Value interface{}
 }
+
+// GetNumber returns the value of a numeric bound, written as a go number; strings are accepted when they are numbers
+func (validation *Validation) GetNumber() (string, bool) {
+	switch value := validation.Value.(type) {
+	case int:
+		return strconv.Itoa(value), true
+	case int64:
+		return strconv.FormatInt(value, 10), true
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), true
+	case string:
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// GetLength returns the value of a length rule, a non negative integer
+func (validation *Validation) GetLength() (int, bool) {
+	number, ok := validation.GetNumber()
+	if !ok {
+		return 0, false
+	}
+	length, err := strconv.ParseFloat(number, 64)
+	if err != nil || length < 0 || length != math.Trunc(length) || length > math.MaxInt32 {
+		return 0, false
+	}
+	return int(length), true
+}
+
+// GetValues returns the values of a list rule, such as oneof or mimetypes
+func (validation *Validation) GetValues() ([]string, bool) {
+	switch value := validation.Value.(type) {
+	case []string:
+		return value, len(value) > 0
+	case []interface{}:
+		values := []string{}
+		for _, item := range value {
+			values = append(values, fmt.Sprint(item))
+		}
+		return values, len(values) > 0
+	}
+	return nil, false
+}
+
+// GetSize returns the value of a maxSize rule in bytes
+func (validation *Validation) GetSize() (int64, bool) {
+	if value, ok := validation.Value.(string); ok {
+		value = strings.ToUpper(strings.TrimSpace(value))
+		for _, unit := range sizeUnits {
+			if number, found := strings.CutSuffix(value, unit.suffix); found {
+				size, err := strconv.ParseInt(strings.TrimSpace(number), 10, 64)
+				if err != nil || size <= 0 || size > math.MaxInt64/unit.bytes {
+					return 0, false
+				}
+				return size * unit.bytes, true
+			}
+		}
+	}
+	size, ok := validation.GetLength()
+	return int64(size), ok && size > 0
+}
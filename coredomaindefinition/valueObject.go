@@ -0,0 +1,11 @@
+package coredomaindefinition
+
+// ValueObject is a group of fields without identity, stored in the columns of the model using it
+type ValueObject struct {
+	Name   string
+	Fields []*Field
+}
+
+func (v ValueObject) GetType() string {
+	return v.Name
+}
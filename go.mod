@@ -2,7 +2,10 @@ module github.com/cleogithub/golem
 
 go 1.22.5
 
-require github.com/cleogithub/golem-common v0.0.0-20241017055819-ce53b6c3aece
+require (
+	github.com/cleogithub/golem-common v0.0.0-20241017055819-ce53b6c3aece
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/segmentio/go-camelcase v0.0.0-20160726192923-7085f1e3c734 // indirect
@@ -68,6 +68,11 @@ var CommonPkgs = map[string]*model.GoPkg{
 		ShortName: "clause",
 		FullName:  "gorm.io/gorm/clause",
 	},
+	"gorm/schema": {
+		Alias:     "schema",
+		ShortName: "schema",
+		FullName:  "gorm.io/gorm/schema",
+	},
 	"http": {
 		Alias:     "http",
 		ShortName: "http",
@@ -88,6 +93,36 @@ var CommonPkgs = map[string]*model.GoPkg{
 		ShortName: "slices",
 		FullName:  "slices",
 	},
+	"regexp": {
+		Alias:     "regexp",
+		ShortName: "regexp",
+		FullName:  "regexp",
+	},
+	"sync": {
+		Alias:     "sync",
+		ShortName: "sync",
+		FullName:  "sync",
+	},
+	"net": {
+		Alias:     "net",
+		ShortName: "net",
+		FullName:  "net",
+	},
+	"mail": {
+		Alias:     "mail",
+		ShortName: "mail",
+		FullName:  "net/mail",
+	},
+	"url": {
+		Alias:     "url",
+		ShortName: "url",
+		FullName:  "net/url",
+	},
+	"utf8": {
+		Alias:     "utf8",
+		ShortName: "utf8",
+		FullName:  "unicode/utf8",
+	},
 	"httpclient": {
 		Alias:     "httpclient",
 		ShortName: "httpclient",
@@ -2,7 +2,11 @@ package consts
 
 import "github.com/cleogithub/golem/coredomaindefinition"
 
-const ID = "Id"
+const (
+	ID         = "Id"
+	CREATED_AT = "CreatedAt"
+	UPDATED_AT = "UpdatedAt"
+)
 
 var DefaultModelFields = []*coredomaindefinition.Field{
 	{
@@ -10,11 +14,13 @@ var DefaultModelFields = []*coredomaindefinition.Field{
 		Type: coredomaindefinition.PrimitiveTypeString,
 	},
 	{
-		Name: "createdAt",
-		Type: coredomaindefinition.PrimitiveTypeDateTime,
+		Name:    "createdAt",
+		Type:    coredomaindefinition.PrimitiveTypeDateTime,
+		Default: coredomaindefinition.DefaultGeneratorNow,
 	},
 	{
-		Name: "updatedAt",
-		Type: coredomaindefinition.PrimitiveTypeDateTime,
+		Name:    "updatedAt",
+		Type:    coredomaindefinition.PrimitiveTypeDateTime,
+		Default: coredomaindefinition.DefaultGeneratorNow,
 	},
 }
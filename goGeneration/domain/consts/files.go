@@ -0,0 +1,57 @@
+package consts
+
+import "github.com/cleogithub/golem/coredomaindefinition"
+
+const (
+	// GO_VERSION is the go version of generated modules and workspace
+	GO_VERSION = "1.22"
+
+	GO_WORK_FILE = "go.work"
+	GO_MOD_FILE  = "go.mod"
+	GO_SUM_FILE  = "go.sum"
+
+	// MANIFEST_FILE lists the files generated in a module with their content hash
+	MANIFEST_FILE = "golem.manifest.json"
+
+	// GENERATED_FILE_MARK is in the name of every generated go and js file
+	GENERATED_FILE_MARK = ".golem."
+
+	// ROLES_FOLDER is the folder of the shared platform roles package
+	ROLES_FOLDER = coredomaindefinition.ROLES_FOLDER
+)
+
+// GoModRequirements are the modules imported by generated code with the version they are pinned to in go.mod
+var GoModRequirements = map[string]string{
+	"github.com/cleogithub/golem-common": "v0.0.0-20241017055819-ce53b6c3aece",
+	"github.com/google/uuid":             "v1.6.0",
+	"gorm.io/gorm":                       "v1.25.12",
+}
+
+// GoModIndirectRequirements are the modules required by GoModRequirements, pinned so the generated module builds without go mod tidy
+var GoModIndirectRequirements = map[string]string{
+	"github.com/jinzhu/inflection":      "v1.0.0",
+	"github.com/jinzhu/now":             "v1.1.5",
+	"github.com/segmentio/go-camelcase": "v0.0.0-20160726192923-7085f1e3c734",
+	"github.com/segmentio/go-snakecase": "v1.2.0",
+	"golang.org/x/text":                 "v0.14.0",
+}
+
+// GoSum are the checksums of the modules and go.mod files of GoModRequirements and GoModIndirectRequirements
+var GoSum = []string{
+	"github.com/cleogithub/golem-common v0.0.0-20241017055819-ce53b6c3aece h1:pKGea5D/7K6XqDDVdjZvZRKMLnIwDKfRGRwyjs8/qio=",
+	"github.com/cleogithub/golem-common v0.0.0-20241017055819-ce53b6c3aece/go.mod h1:k8SonkhbEi5oDe4gXZ+KSc1w+cDtXy9repfo5NcHVDI=",
+	"github.com/google/uuid v1.6.0 h1:NIvaJDMOsjHA8n1jAhLSgzrAzy1Hgr+hNrb57e+94F0=",
+	"github.com/google/uuid v1.6.0/go.mod h1:TIyPZe4MgqvfeYDBFedMoGGpEw/LqOeaOT+nhxU+yHo=",
+	"github.com/jinzhu/inflection v1.0.0 h1:K317FqzuhWc8YvSVlFMCCUb36O/S9MCKRDI7QkRKD/E=",
+	"github.com/jinzhu/inflection v1.0.0/go.mod h1:h+uFLlag+Qp1Va5pdKtLDYj+kHp5pxUVkryuEj+Srlc=",
+	"github.com/jinzhu/now v1.1.5 h1:/o9tlHleP7gOFmsnYNz3RGnqzefHA47wQpKrrdTIwXQ=",
+	"github.com/jinzhu/now v1.1.5/go.mod h1:d3SSVoowX0Lcu0IBviAWJpolVfI5UJVZZ7cO71lE/z8=",
+	"github.com/segmentio/go-camelcase v0.0.0-20160726192923-7085f1e3c734 h1:Cpx2WLIv6fuPvaJAHNhYOgYzk/8RcJXu/8+mOrxf2KM=",
+	"github.com/segmentio/go-camelcase v0.0.0-20160726192923-7085f1e3c734/go.mod h1:hqVOMAwu+ekffC3Tvq5N1ljnXRrFKcaSjbCmQ8JgYaI=",
+	"github.com/segmentio/go-snakecase v1.2.0 h1:4cTmEjPGi03WmyAHWBjX53viTpBkn/z+4DO++fqYvpw=",
+	"github.com/segmentio/go-snakecase v1.2.0/go.mod h1:jk1miR5MS7Na32PZUykG89Arm+1BUSYhuGR6b7+hJto=",
+	"golang.org/x/text v0.14.0 h1:ScX5w1eTa3QqT8oi6+ziP7dTV1S2+ALU0bI+0zXKWiQ=",
+	"golang.org/x/text v0.14.0/go.mod h1:18ZOQIKpY8NJVqYksKHtTdi31H5itFRjB5/qKTNYzSU=",
+	"gorm.io/gorm v1.25.12 h1:I0u8i2hWQItBq1WfE0o2+WuL9+8L21K9e2HHSTE/0f8=",
+	"gorm.io/gorm v1.25.12/go.mod h1:xh7N7RHfYlNc5EmcI/El95gXusucDrQnHXe0+CgWcLQ=",
+}
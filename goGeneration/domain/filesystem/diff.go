@@ -0,0 +1,209 @@
+package filesystem
+
+import (
+	"fmt"
+	"strings"
+)
+
+type FileChangeType string
+
+const (
+	FileChangeTypeAdded    FileChangeType = "added"
+	FileChangeTypeModified FileChangeType = "modified"
+	FileChangeTypeDeleted  FileChangeType = "deleted"
+)
+
+// FileChange is the effect of a generation on a file
+type FileChange struct {
+	Path string
+	Type FileChangeType
+	// Diff is the unified diff between the previous and the new content
+	Diff string
+}
+
+// DIFF_CONTEXT is the number of unchanged lines around each hunk
+const DIFF_CONTEXT = 3
+
+type diffLine struct {
+	op   byte // ' ', '-' or '+'
+	text string
+}
+
+// UnifiedDiff returns the unified diff from old to new content, empty if they are equal.
+func UnifiedDiff(name string, old string, new string) string {
+	if old == new {
+		return ""
+	}
+
+	oldLines := splitLines(old)
+	newLines := splitLines(new)
+	lines := diffLines(oldLines, newLines)
+
+	name = strings.TrimPrefix(name, "/")
+	oldName, newName := "a/"+name, "b/"+name
+	if old == "" {
+		oldName = "/dev/null"
+	}
+	if new == "" {
+		newName = "/dev/null"
+	}
+	str := fmt.Sprintf("--- %s\n+++ %s\n", oldName, newName)
+
+	// group changes closer than twice the context in the same hunk
+	for start := 0; start < len(lines); {
+		if lines[start].op == ' ' {
+			start++
+			continue
+		}
+		from := max(start-DIFF_CONTEXT, 0)
+		end := start
+		for unchanged := 0; end < len(lines) && unchanged <= 2*DIFF_CONTEXT; end++ {
+			if lines[end].op == ' ' {
+				unchanged++
+			} else {
+				unchanged = 0
+			}
+		}
+		// trim trailing context to DIFF_CONTEXT lines
+		to := end
+		for to > from && lines[to-1].op == ' ' {
+			to--
+		}
+		to = min(to+DIFF_CONTEXT, len(lines))
+
+		oldStart, newStart := 1, 1
+		for _, l := range lines[:from] {
+			if l.op != '+' {
+				oldStart++
+			}
+			if l.op != '-' {
+				newStart++
+			}
+		}
+		oldCount, newCount := 0, 0
+		hunk := ""
+		for _, l := range lines[from:to] {
+			if l.op != '+' {
+				oldCount++
+			}
+			if l.op != '-' {
+				newCount++
+			}
+			hunk += string(l.op) + l.text + "\n"
+		}
+		if oldCount == 0 {
+			oldStart--
+		}
+		if newCount == 0 {
+			newStart--
+		}
+		str += fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount) + hunk
+
+		start = to
+	}
+
+	return str
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines computes the shortest edit script from a to b.
+// The common prefix and suffix are trimmed, the rest is diffed with the Myers algorithm.
+func diffLines(a []string, b []string) []diffLine {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	lines := []diffLine{}
+	for _, text := range a[:prefix] {
+		lines = append(lines, diffLine{op: ' ', text: text})
+	}
+	lines = append(lines, myersDiff(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, text := range a[len(a)-suffix:] {
+		lines = append(lines, diffLine{op: ' ', text: text})
+	}
+	return lines
+}
+
+// myersDiff returns the shortest edit script from a to b, deletions before insertions.
+// Only the diagonals reachable after each edit are kept, so memory grows with the square of the number of edits,
+// not with the product of the lengths.
+func myersDiff(a []string, b []string) []diffLine {
+	n, m := len(a), len(b)
+	// trace[d][k+d] is the furthest x reached on diagonal k = x - y with d edits
+	trace := [][]int{}
+	for d := 0; d <= n+m; d++ {
+		v := make([]int, 2*d+1)
+		for k := -d; k <= d; k += 2 {
+			x := 0
+			if d > 0 {
+				prev := trace[d-1]
+				if k == -d || (k != d && prev[k-1+d-1] < prev[k+1+d-1]) {
+					// insertion from diagonal k+1
+					x = prev[k+1+d-1]
+				} else {
+					// deletion from diagonal k-1
+					x = prev[k-1+d-1] + 1
+				}
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[k+d] = x
+			if x >= n && y >= m {
+				return myersBacktrack(a, b, append(trace, v))
+			}
+		}
+		trace = append(trace, v)
+	}
+	return nil
+}
+
+// myersBacktrack walks the trace back from the end of a and b to build the edit script
+func myersBacktrack(a []string, b []string, trace [][]int) []diffLine {
+	reversed := []diffLine{}
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d > 0; d-- {
+		prev := trace[d-1]
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && prev[k-1+d-1] < prev[k+1+d-1]) {
+			prevK = k + 1
+		}
+		prevX := prev[prevK+d-1]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			reversed = append(reversed, diffLine{op: ' ', text: a[x-1]})
+			x--
+			y--
+		}
+		if x == prevX {
+			reversed = append(reversed, diffLine{op: '+', text: b[y-1]})
+			y--
+		} else {
+			reversed = append(reversed, diffLine{op: '-', text: a[x-1]})
+			x--
+		}
+	}
+	for ; x > 0 && y > 0; x, y = x-1, y-1 {
+		reversed = append(reversed, diffLine{op: ' ', text: a[x-1]})
+	}
+
+	lines := make([]diffLine, 0, len(reversed))
+	for i := len(reversed) - 1; i >= 0; i-- {
+		lines = append(lines, reversed[i])
+	}
+	return lines
+}
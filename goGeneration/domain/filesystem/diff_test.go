@@ -0,0 +1,177 @@
+package filesystem
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	// lines 1 to 20
+	numbered := func(replace map[int]string) string {
+		str := ""
+		for i := 1; i <= 20; i++ {
+			line, ok := replace[i]
+			if !ok {
+				line = fmt.Sprint(i)
+			}
+			if line != "" {
+				str += line + "\n"
+			}
+		}
+		return str
+	}
+
+	tests := []struct {
+		name     string
+		old      string
+		new      string
+		expected string
+	}{
+		{name: "equal", old: "a\nb\n", new: "a\nb\n", expected: ""},
+		{
+			name:     "added file",
+			old:      "",
+			new:      "a\nb\n",
+			expected: "--- /dev/null\n+++ b/f.go\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name:     "deleted file",
+			old:      "a\nb\n",
+			new:      "",
+			expected: "--- a/f.go\n+++ /dev/null\n@@ -1,2 +0,0 @@\n-a\n-b\n",
+		},
+		{
+			name:     "modified line with context",
+			old:      numbered(nil),
+			new:      numbered(map[int]string{10: "ten"}),
+			expected: "--- a/f.go\n+++ b/f.go\n@@ -7,7 +7,7 @@\n 7\n 8\n 9\n-10\n+ten\n 11\n 12\n 13\n",
+		},
+		{
+			name:     "added and removed lines",
+			old:      numbered(map[int]string{2: ""}),
+			new:      numbered(map[int]string{19: ""}),
+			expected: "--- a/f.go\n+++ b/f.go\n@@ -1,4 +1,5 @@\n 1\n+2\n 3\n 4\n 5\n@@ -15,5 +16,4 @@\n 16\n 17\n 18\n-19\n 20\n",
+		},
+		{
+			name:     "close changes share a hunk",
+			old:      numbered(nil),
+			new:      numbered(map[int]string{5: "five", 11: "eleven"}),
+			expected: "--- a/f.go\n+++ b/f.go\n@@ -2,13 +2,13 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n 9\n 10\n-11\n+eleven\n 12\n 13\n 14\n",
+		},
+		{
+			name:     "trailing newline is not a line",
+			old:      "a\n",
+			new:      "a\nb",
+			expected: "--- a/f.go\n+++ b/f.go\n@@ -1,1 +1,2 @@\n a\n+b\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := UnifiedDiff("/f.go", test.old, test.new)
+			if actual != test.expected {
+				t.Fatalf("expected\n%s\ngot\n%s", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected string
+	}{
+		{name: "empty", a: "", b: "", expected: ""},
+		{name: "insertion", a: "ac", b: "abc", expected: " a+b c"},
+		{name: "deletion", a: "abc", b: "ac", expected: " a-b c"},
+		{name: "replacement removes first", a: "axc", b: "ayc", expected: " a-x+y c"},
+		{name: "everything changed", a: "ab", b: "cd", expected: "-a-b+c+d"},
+		{name: "moved line", a: "abcd", b: "bcda", expected: "-a b c d+a"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := ""
+			for _, l := range diffLines(strings.Split(test.a, ""), strings.Split(test.b, "")) {
+				actual += string(l.op) + l.text
+			}
+			if actual != test.expected {
+				t.Fatalf("expected %q, got %q", test.expected, actual)
+			}
+		})
+	}
+}
+
+// TestDiffLinesIsMinimal compares random diffs with the longest common subsequence
+func TestDiffLinesIsMinimal(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	randomLines := func() []string {
+		lines := make([]string, random.Intn(30))
+		for i := range lines {
+			lines[i] = string(rune('a' + random.Intn(4)))
+		}
+		return lines
+	}
+
+	for i := 0; i < 500; i++ {
+		a, b := randomLines(), randomLines()
+		lines := diffLines(a, b)
+
+		oldLines, newLines, unchanged := []string{}, []string{}, 0
+		for _, l := range lines {
+			if l.op != '+' {
+				oldLines = append(oldLines, l.text)
+			}
+			if l.op != '-' {
+				newLines = append(newLines, l.text)
+			}
+			if l.op == ' ' {
+				unchanged++
+			}
+		}
+		if strings.Join(oldLines, "") != strings.Join(a, "") || strings.Join(newLines, "") != strings.Join(b, "") {
+			t.Fatalf("diff of %v and %v does not rebuild them: %v", a, b, lines)
+		}
+		if expected := lcsLength(a, b); unchanged != expected {
+			t.Fatalf("diff of %v and %v keeps %d lines, expected %d", a, b, unchanged, expected)
+		}
+	}
+}
+
+func TestDiffLinesOnLargeFiles(t *testing.T) {
+	a := make([]string, 100000)
+	for i := range a {
+		a[i] = fmt.Sprint(i)
+	}
+	b := append([]string{}, a...)
+	b[10], b[50000], b[99990] = "x", "y", "z"
+
+	changed := 0
+	for _, l := range diffLines(a, b) {
+		if l.op != ' ' {
+			changed++
+		}
+	}
+	if changed != 6 {
+		t.Fatalf("expected 6 changed lines, got %d", changed)
+	}
+}
+
+func lcsLength(a []string, b []string) int {
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+	return lengths[0][0]
+}
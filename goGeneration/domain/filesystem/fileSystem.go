@@ -0,0 +1,15 @@
+package filesystem
+
+// FileSystem is the output of a generation. Paths are slash separated.
+type FileSystem interface {
+	// ReadFile returns the content of the file, an error satisfying errors.Is(err, fs.ErrNotExist) if it does not exist
+	ReadFile(path string) ([]byte, error)
+	// WriteFile creates or replaces the file, creating missing parent folders
+	WriteFile(path string, content []byte) error
+	// Remove deletes the file
+	Remove(path string) error
+	// Exists reports whether the file or folder exists
+	Exists(path string) (bool, error)
+	// ListFiles returns every file under root, recursively
+	ListFiles(root string) ([]string, error)
+}
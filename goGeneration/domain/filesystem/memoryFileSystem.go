@@ -0,0 +1,186 @@
+package filesystem
+
+import (
+	"bytes"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/cleogithub/golem-common/pkg/merror"
+)
+
+// MemoryFileSystem keeps files in memory.
+// When Base is set, it is an overlay: reads fall back to Base and every write or removal stays in memory,
+// so Changes returns the effect the operations would have on Base.
+type MemoryFileSystem struct {
+	Base FileSystem
+
+	files   map[string][]byte
+	deleted map[string]bool
+}
+
+var _ FileSystem = &MemoryFileSystem{}
+
+// NewMemoryFileSystem returns an empty in-memory file system, or an overlay of base if not nil
+func NewMemoryFileSystem(base FileSystem) *MemoryFileSystem {
+	return &MemoryFileSystem{
+		Base:    base,
+		files:   map[string][]byte{},
+		deleted: map[string]bool{},
+	}
+}
+
+func (m *MemoryFileSystem) init() {
+	if m.files == nil {
+		m.files = map[string][]byte{}
+	}
+	if m.deleted == nil {
+		m.deleted = map[string]bool{}
+	}
+}
+
+// ReadFile implements FileSystem.
+func (m *MemoryFileSystem) ReadFile(name string) ([]byte, error) {
+	name = path.Clean(name)
+	if content, ok := m.files[name]; ok {
+		return bytes.Clone(content), nil
+	}
+	if m.Base == nil || m.deleted[name] {
+		return nil, merror.Stack(&fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist})
+	}
+	return m.Base.ReadFile(name)
+}
+
+// WriteFile implements FileSystem.
+func (m *MemoryFileSystem) WriteFile(name string, content []byte) error {
+	m.init()
+	name = path.Clean(name)
+	m.files[name] = bytes.Clone(content)
+	delete(m.deleted, name)
+	return nil
+}
+
+// Remove implements FileSystem.
+func (m *MemoryFileSystem) Remove(name string) error {
+	m.init()
+	name = path.Clean(name)
+	exists, err := m.Exists(name)
+	if err != nil {
+		return merror.Stack(err)
+	}
+	if !exists {
+		return merror.Stack(&fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist})
+	}
+	delete(m.files, name)
+	if m.Base != nil {
+		m.deleted[name] = true
+	}
+	return nil
+}
+
+// Exists implements FileSystem.
+func (m *MemoryFileSystem) Exists(name string) (bool, error) {
+	name = path.Clean(name)
+	if _, ok := m.files[name]; ok {
+		return true, nil
+	}
+	for f := range m.files {
+		if name == "." || strings.HasPrefix(f, name+"/") {
+			return true, nil
+		}
+	}
+	if m.Base == nil || m.deleted[name] {
+		return false, nil
+	}
+	// a folder of Base exists as long as one of its files is not removed in memory
+	for f := range m.deleted {
+		if name == "." || strings.HasPrefix(f, name+"/") {
+			files, err := m.ListFiles(name)
+			if err != nil {
+				return false, merror.Stack(err)
+			}
+			return len(files) > 0, nil
+		}
+	}
+	return m.Base.Exists(name)
+}
+
+// ListFiles implements FileSystem.
+func (m *MemoryFileSystem) ListFiles(root string) ([]string, error) {
+	root = path.Clean(root)
+	found := map[string]bool{}
+
+	if m.Base != nil {
+		files, err := m.Base.ListFiles(root)
+		if err != nil {
+			return nil, merror.Stack(err)
+		}
+		for _, f := range files {
+			if !m.deleted[path.Clean(f)] {
+				found[path.Clean(f)] = true
+			}
+		}
+	}
+	for f := range m.files {
+		if f == root || strings.HasPrefix(f, root+"/") || root == "." {
+			found[f] = true
+		}
+	}
+
+	files := []string{}
+	for f := range found {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Changes returns the files added, modified or deleted in memory compared to Base, sorted by path.
+// Without Base, every file is added.
+func (m *MemoryFileSystem) Changes() ([]*FileChange, error) {
+	changes := []*FileChange{}
+
+	for name, content := range m.files {
+		old := []byte{}
+		changeType := FileChangeTypeAdded
+		if m.Base != nil {
+			exists, err := m.Base.Exists(name)
+			if err != nil {
+				return nil, merror.Stack(err)
+			}
+			if exists {
+				old, err = m.Base.ReadFile(name)
+				if err != nil {
+					return nil, merror.Stack(err)
+				}
+				if bytes.Equal(old, content) {
+					continue
+				}
+				changeType = FileChangeTypeModified
+			}
+		}
+		changes = append(changes, &FileChange{
+			Path: name,
+			Type: changeType,
+			Diff: UnifiedDiff(name, string(old), string(content)),
+		})
+	}
+
+	for name := range m.deleted {
+		old, err := m.Base.ReadFile(name)
+		if err != nil {
+			return nil, merror.Stack(err)
+		}
+		changes = append(changes, &FileChange{
+			Path: name,
+			Type: FileChangeTypeDeleted,
+			Diff: UnifiedDiff(name, string(old), ""),
+		})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes, nil
+}
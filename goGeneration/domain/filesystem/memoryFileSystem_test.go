@@ -0,0 +1,43 @@
+package filesystem
+
+import (
+	"testing"
+)
+
+func TestMemoryFileSystemExists(t *testing.T) {
+	base := NewMemoryFileSystem(nil)
+	base.WriteFile("app/main.go", []byte("package main"))
+	base.WriteFile("app/user/user.go", []byte("package user"))
+
+	tests := []struct {
+		name     string
+		setup    func(m *MemoryFileSystem)
+		path     string
+		expected bool
+	}{
+		{name: "base file", path: "app/main.go", expected: true},
+		{name: "base folder", path: "app/user", expected: true},
+		{name: "missing file", path: "app/shop.go", expected: false},
+		{name: "file written in memory", setup: func(m *MemoryFileSystem) { m.WriteFile("app/shop/shop.go", nil) }, path: "app/shop/shop.go", expected: true},
+		{name: "folder written in memory", setup: func(m *MemoryFileSystem) { m.WriteFile("app/shop/shop.go", nil) }, path: "app/shop", expected: true},
+		{name: "removed file", setup: func(m *MemoryFileSystem) { m.Remove("app/main.go") }, path: "app/main.go", expected: false},
+		{name: "folder with its files removed", setup: func(m *MemoryFileSystem) { m.Remove("app/user/user.go") }, path: "app/user", expected: false},
+		{name: "folder with files left", setup: func(m *MemoryFileSystem) { m.Remove("app/user/user.go") }, path: "app", expected: true},
+		{name: "removed then written", setup: func(m *MemoryFileSystem) { m.Remove("app/main.go"); m.WriteFile("app/main.go", nil) }, path: "app/main.go", expected: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := NewMemoryFileSystem(base)
+			if test.setup != nil {
+				test.setup(m)
+			}
+			exists, err := m.Exists(test.path)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if exists != test.expected {
+				t.Fatalf("expected %v, got %v", test.expected, exists)
+			}
+		})
+	}
+}
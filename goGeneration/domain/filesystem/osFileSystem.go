@@ -0,0 +1,77 @@
+package filesystem
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/cleogithub/golem-common/pkg/merror"
+)
+
+// OSFileSystem writes on disk
+type OSFileSystem struct {
+}
+
+var _ FileSystem = &OSFileSystem{}
+
+// ReadFile implements FileSystem.
+func (o *OSFileSystem) ReadFile(path string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, merror.Stack(err)
+	}
+	return content, nil
+}
+
+// WriteFile implements FileSystem.
+func (o *OSFileSystem) WriteFile(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return merror.Stack(err)
+	}
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return merror.Stack(err)
+	}
+	return nil
+}
+
+// Remove implements FileSystem.
+func (o *OSFileSystem) Remove(path string) error {
+	if err := os.Remove(path); err != nil {
+		return merror.Stack(err)
+	}
+	return nil
+}
+
+// Exists implements FileSystem.
+func (o *OSFileSystem) Exists(path string) (bool, error) {
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, merror.Stack(err)
+	}
+	return true, nil
+}
+
+// ListFiles implements FileSystem.
+func (o *OSFileSystem) ListFiles(root string) ([]string, error) {
+	files := []string{}
+	if exists, err := o.Exists(root); err != nil || !exists {
+		return files, err
+	}
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			files = append(files, filepath.ToSlash(path))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, merror.Stack(err)
+	}
+
+	return files, nil
+}
@@ -0,0 +1,240 @@
+package domainbuilder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cleogithub/golem/coredomaindefinition"
+	"github.com/cleogithub/golem/goGeneration/domain/consts"
+	"github.com/cleogithub/golem/goGeneration/domain/model"
+)
+
+const (
+	POSTGRES_ARRAY_SERIALIZER_NAME = "PostgresArraySerializer"
+	// name of the serializer in gorm tags
+	POSTGRES_ARRAY_SERIALIZER          = "postgres_array"
+	POSTGRES_ARRAY_PARSE_NAME          = "parsePostgresArray"
+	POSTGRES_ARRAY_SET_ELEMENT_NAME    = "setPostgresArrayElement"
+	POSTGRES_ARRAY_SERIALIZER_RECEIVER = "serializer"
+)
+
+// GetArrayGormTags returns the gorm tag values storing an array field:
+// a native array for primitives with the postgres dialect, a JSON column otherwise.
+// Bytes are stored as is.
+func (domainBuilder *domainBuilder) GetArrayGormTags(ctx context.Context, field *coredomaindefinition.Field, array *coredomaindefinition.Array) []string {
+	if array.Type == coredomaindefinition.PrimitiveTypeByte {
+		return nil
+	}
+
+	if domainBuilder.GetDialect(ctx) == coredomaindefinition.DialectPostgres {
+		if columnType := getPostgresArrayElementType(ctx, field, array.Type); columnType != "" {
+			return []string{"serializer:" + POSTGRES_ARRAY_SERIALIZER, "type:" + columnType + "[]"}
+		}
+	}
+	return []string{"serializer:json"}
+}
+
+// GetDialect returns the database dialect of the gorm adapter, empty when not configured
+func (domainBuilder *domainBuilder) GetDialect(ctx context.Context) coredomaindefinition.Dialect {
+	if domainBuilder.Definition.Configuration == nil {
+		return ""
+	}
+	return domainBuilder.Definition.Configuration.Dialect
+}
+
+// getPostgresArrayElementType returns the postgres type of the elements of a native array, empty when t can not be one
+func getPostgresArrayElementType(ctx context.Context, field *coredomaindefinition.Field, t coredomaindefinition.Type) string {
+	if _, ok := t.(*coredomaindefinition.Enum); ok {
+		return "text"
+	}
+	switch t {
+	case coredomaindefinition.PrimitiveTypeString:
+		return "text"
+	case coredomaindefinition.PrimitiveTypeInt:
+		return "bigint"
+	case coredomaindefinition.PrimitiveTypeFloat:
+		return "double precision"
+	case coredomaindefinition.PrimitiveTypeBool:
+		return "boolean"
+	case coredomaindefinition.PrimitiveTypeDecimal:
+		return GetDecimalColumnType(ctx, field)
+	case coredomaindefinition.PrimitiveTypeDate, coredomaindefinition.PrimitiveTypeTime:
+		return getCivilType(t).column
+	}
+	return ""
+}
+
+// addPostgresArraySerializer adds the gorm serializer of native postgres arrays, registered on init.
+// It converts slices of strings, ints, floats and bools, their named types included, from and to the postgres array literal.
+func (builder *GormDomainRepositoryBuilder) addPostgresArraySerializer(ctx context.Context) {
+	if builder.Err != nil || builder.DomainBuilder.GetDialect(ctx) != coredomaindefinition.DialectPostgres {
+		return
+	}
+
+	serializer := &model.Struct{
+		Name:   POSTGRES_ARRAY_SERIALIZER_NAME,
+		Fields: []*model.Field{},
+	}
+	schemaField := &model.PointerType{
+		Type: &model.PkgReference{Pkg: consts.CommonPkgs["gorm/schema"], Reference: &model.ExternalType{Type: "Field"}},
+	}
+	reflectValue := &model.PkgReference{Pkg: consts.CommonPkgs["reflect"], Reference: &model.ExternalType{Type: "Value"}}
+	strconvPkg := &model.GoPkg{Alias: "strconv", ShortName: "strconv", FullName: "strconv"}
+
+	register := &model.Function{
+		Name: "init",
+		Content: func() (string, []*model.GoPkg) {
+			str := fmt.Sprintf(`%s.RegisterSerializer("%s", %s{})`, consts.CommonPkgs["gorm/schema"].Alias, POSTGRES_ARRAY_SERIALIZER, POSTGRES_ARRAY_SERIALIZER_NAME)
+			return str, []*model.GoPkg{consts.CommonPkgs["gorm/schema"]}
+		},
+	}
+
+	scan := &model.Function{
+		On:     serializer,
+		OnName: POSTGRES_ARRAY_SERIALIZER_RECEIVER,
+		Name:   "Scan",
+		Args: []*model.Param{
+			CTX,
+			{Name: "field", Type: schemaField},
+			{Name: "dst", Type: reflectValue},
+			{Name: "dbValue", Type: model.PrimitiveTypeInterface},
+		},
+		Results: []*model.Param{{Type: model.PrimitiveTypeError}},
+		Content: func() (string, []*model.GoPkg) {
+			str := "array := reflect.Zero(field.FieldType)" + consts.LN
+			str += "if dbValue != nil {" + consts.LN
+			str += "var literal string" + consts.LN
+			str += "switch v := dbValue.(type) {" + consts.LN
+			str += "case []byte:" + consts.LN
+			str += "literal = string(v)" + consts.LN
+			str += "case string:" + consts.LN
+			str += "literal = v" + consts.LN
+			str += "default:" + consts.LN
+			str += `return fmt.Errorf("unexpected postgres array %T", dbValue)` + consts.LN
+			str += "}" + consts.LN
+			str += fmt.Sprintf("elements, err := %s(literal)", POSTGRES_ARRAY_PARSE_NAME) + consts.LN
+			str += "if err != nil {" + consts.LN
+			str += "return err" + consts.LN
+			str += "}" + consts.LN
+			str += "array = reflect.MakeSlice(field.FieldType, len(elements), len(elements))" + consts.LN
+			str += "for i, element := range elements {" + consts.LN
+			str += fmt.Sprintf("if err := %s(array.Index(i), element); err != nil {", POSTGRES_ARRAY_SET_ELEMENT_NAME) + consts.LN
+			str += "return err" + consts.LN
+			str += "}" + consts.LN
+			str += "}" + consts.LN
+			str += "}" + consts.LN
+			str += "field.ReflectValueOf(ctx, dst).Set(array)" + consts.LN
+			str += "return nil"
+			return str, []*model.GoPkg{consts.CommonPkgs["fmt"], consts.CommonPkgs["reflect"]}
+		},
+	}
+
+	value := &model.Function{
+		On:     serializer,
+		OnName: POSTGRES_ARRAY_SERIALIZER_RECEIVER,
+		Name:   "Value",
+		Args: []*model.Param{
+			CTX,
+			{Name: "field", Type: schemaField},
+			{Name: "dst", Type: reflectValue},
+			{Name: "fieldValue", Type: model.PrimitiveTypeInterface},
+		},
+		Results: []*model.Param{{Type: model.PrimitiveTypeInterface}, {Type: model.PrimitiveTypeError}},
+		Content: func() (string, []*model.GoPkg) {
+			str := "array := reflect.ValueOf(fieldValue)" + consts.LN
+			str += "if fieldValue == nil || array.IsNil() {" + consts.LN
+			str += "return nil, nil" + consts.LN
+			str += "}" + consts.LN
+			str += "elements := make([]string, array.Len())" + consts.LN
+			str += "for i := range elements {" + consts.LN
+			str += "element := array.Index(i)" + consts.LN
+			str += "switch element.Kind() {" + consts.LN
+			str += "case reflect.String:" + consts.LN
+			str += "elements[i] = `\"` + strings.NewReplacer(`\\`, `\\\\`, `\"`, `\\\"`).Replace(element.String()) + `\"`" + consts.LN
+			str += "case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:" + consts.LN
+			str += "elements[i] = strconv.FormatInt(element.Int(), 10)" + consts.LN
+			str += "case reflect.Float32, reflect.Float64:" + consts.LN
+			str += "elements[i] = strconv.FormatFloat(element.Float(), 'g', -1, 64)" + consts.LN
+			str += "case reflect.Bool:" + consts.LN
+			str += "elements[i] = strconv.FormatBool(element.Bool())" + consts.LN
+			str += "default:" + consts.LN
+			str += `return nil, fmt.Errorf("unsupported postgres array element %s", element.Type())` + consts.LN
+			str += "}" + consts.LN
+			str += "}" + consts.LN
+			str += `return "{" + strings.Join(elements, ",") + "}", nil`
+			return str, []*model.GoPkg{consts.CommonPkgs["fmt"], consts.CommonPkgs["reflect"], consts.CommonPkgs["strings"], strconvPkg}
+		},
+	}
+
+	parse := &model.Function{
+		Name:    POSTGRES_ARRAY_PARSE_NAME,
+		Args:    []*model.Param{{Name: "literal", Type: model.PrimitiveTypeString}},
+		Results: []*model.Param{{Type: &model.ArrayType{Type: model.PrimitiveTypeString}}, {Type: model.PrimitiveTypeError}},
+		Content: func() (string, []*model.GoPkg) {
+			str := `if len(literal) < 2 || literal[0] != '{' || literal[len(literal)-1] != '}' {` + consts.LN
+			str += `return nil, fmt.Errorf("invalid postgres array %s", literal)` + consts.LN
+			str += "}" + consts.LN
+			str += "elements := []string{}" + consts.LN
+			str += "content := literal[1 : len(literal)-1]" + consts.LN
+			str += `if content == "" {` + consts.LN
+			str += "return elements, nil" + consts.LN
+			str += "}" + consts.LN
+			str += "element := strings.Builder{}" + consts.LN
+			str += "quoted, escaped := false, false" + consts.LN
+			str += "for _, r := range content {" + consts.LN
+			str += "switch {" + consts.LN
+			str += "case escaped:" + consts.LN
+			str += "element.WriteRune(r)" + consts.LN
+			str += "escaped = false" + consts.LN
+			str += `case r == '\\':` + consts.LN
+			str += "escaped = true" + consts.LN
+			str += `case r == '"':` + consts.LN
+			str += "quoted = !quoted" + consts.LN
+			str += "case r == ',' && !quoted:" + consts.LN
+			str += "elements = append(elements, element.String())" + consts.LN
+			str += "element.Reset()" + consts.LN
+			str += "default:" + consts.LN
+			str += "element.WriteRune(r)" + consts.LN
+			str += "}" + consts.LN
+			str += "}" + consts.LN
+			str += "return append(elements, element.String()), nil"
+			return str, []*model.GoPkg{consts.CommonPkgs["fmt"], consts.CommonPkgs["strings"]}
+		},
+	}
+
+	setElement := &model.Function{
+		Name:    POSTGRES_ARRAY_SET_ELEMENT_NAME,
+		Args:    []*model.Param{{Name: "target", Type: reflectValue}, {Name: "element", Type: model.PrimitiveTypeString}},
+		Results: []*model.Param{{Type: model.PrimitiveTypeError}},
+		Content: func() (string, []*model.GoPkg) {
+			str := "switch target.Kind() {" + consts.LN
+			str += "case reflect.String:" + consts.LN
+			str += "target.SetString(element)" + consts.LN
+			str += "case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:" + consts.LN
+			str += "n, err := strconv.ParseInt(element, 10, 64)" + consts.LN
+			str += "if err != nil {" + consts.LN
+			str += "return err" + consts.LN
+			str += "}" + consts.LN
+			str += "target.SetInt(n)" + consts.LN
+			str += "case reflect.Float32, reflect.Float64:" + consts.LN
+			str += "f, err := strconv.ParseFloat(element, 64)" + consts.LN
+			str += "if err != nil {" + consts.LN
+			str += "return err" + consts.LN
+			str += "}" + consts.LN
+			str += "target.SetFloat(f)" + consts.LN
+			str += "case reflect.Bool:" + consts.LN
+			str += `target.SetBool(element == "t" || element == "true")` + consts.LN
+			str += "default:" + consts.LN
+			str += `return fmt.Errorf("unsupported postgres array element %s", target.Type())` + consts.LN
+			str += "}" + consts.LN
+			str += "return nil"
+			return str, []*model.GoPkg{consts.CommonPkgs["fmt"], consts.CommonPkgs["reflect"], strconvPkg}
+		},
+	}
+
+	builder.DomainBuilder.Domain.Files = append(builder.DomainBuilder.Domain.Files, &model.File{
+		Name:     POSTGRES_ARRAY_SERIALIZER_NAME,
+		Pkg:      builder.DomainBuilder.GetGormAdapterPackage(),
+		Elements: []interface{}{register, serializer, scan, value, parse, setElement},
+	})
+}
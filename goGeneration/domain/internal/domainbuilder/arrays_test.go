@@ -0,0 +1,64 @@
+package domainbuilder
+
+import (
+	"testing"
+
+	"github.com/cleogithub/golem/coredomaindefinition"
+)
+
+func TestArrayGeneration(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect coredomaindefinition.Dialect
+		// expected gorm fields
+		expected   []string
+		serializer bool
+	}{
+		{
+			name: "json columns by default",
+			expected: []string{
+				"Tags      []string        `gorm:\"column:tags;serializer:json\"`",
+				"Scores    []int64         `gorm:\"column:scores;serializer:json\"`",
+			},
+		},
+		{
+			name:    "native postgres arrays",
+			dialect: coredomaindefinition.DialectPostgres,
+			expected: []string{
+				"Tags      []string        `gorm:\"column:tags;serializer:postgres_array;type:text[]\"`",
+				"Scores    []int64         `gorm:\"column:scores;serializer:postgres_array;type:bigint[]\"`",
+			},
+			serializer: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			shop := coredomaindefinition.NewModel("shop")
+			shop.Fields = []*coredomaindefinition.Field{
+				{Name: "tags", Type: &coredomaindefinition.Array{Type: coredomaindefinition.PrimitiveTypeString}},
+				{Name: "scores", Type: &coredomaindefinition.Array{Type: coredomaindefinition.PrimitiveTypeInt}},
+			}
+			definition := withCRUD(newTestDomain(shop), shop)
+			definition.Configuration.Dialect = test.dialect
+
+			files := generate(t, definition)
+
+			assertContains(t, files.goFile(t, "adapter/repository/gormadapter/shop"), test.expected...)
+			_, ok := files.goFiles["adapter/repository/gormadapter/postgresArraySerializer"]
+			if ok != test.serializer {
+				t.Fatalf("expected the postgres array serializer to be generated %v, got %v", test.serializer, ok)
+			}
+			if ok {
+				assertContains(t, files.goFile(t, "adapter/repository/gormadapter/postgresArraySerializer"),
+					"schema.RegisterSerializer(\"postgres_array\", PostgresArraySerializer{})",
+					"func parsePostgresArray(literal string) ([]string, error) {",
+				)
+			}
+			assertContains(t, files.jsFile(t, "entities"),
+				"\ttags // string[]\n",
+				"\tscores // number[]\n",
+				"if (Array.isArray(data.tags)) this.tags = data.tags;",
+			)
+		})
+	}
+}
@@ -4,16 +4,11 @@ import (
 	"context"
 
 	"github.com/cleogithub/golem/coredomaindefinition"
+	"github.com/cleogithub/golem/goGeneration/domain/plugin"
 )
 
-type Builder interface {
-	WithModel(ctx context.Context, definition *coredomaindefinition.Model)
-	WithRepository(ctx context.Context, definition *coredomaindefinition.Repository)
-	WithRelation(ctx context.Context, definition *coredomaindefinition.Relation)
-	WithCRUD(ctx context.Context, definition *coredomaindefinition.CRUD)
-	WithUsecase(ctx context.Context, definition *coredomaindefinition.Usecase)
-	Build(ctx context.Context) error
-}
+// Builder is the public plugin interface so custom builders are handled as golem ones
+type Builder = plugin.Builder
 
 // Empty Builder will do nothing except returning a PanicBuilder
 // to avoid using chained call without noticing that a EmptyBuilder war returned instead of the current Builder
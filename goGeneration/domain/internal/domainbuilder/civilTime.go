@@ -0,0 +1,242 @@
+package domainbuilder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cleogithub/golem/coredomaindefinition"
+	"github.com/cleogithub/golem/goGeneration/domain/consts"
+	"github.com/cleogithub/golem/goGeneration/domain/model"
+)
+
+const (
+	DATE_NAME             = "Date"
+	DATE_RECEIVER_NAME    = "date"
+	DATE_LAYOUT           = "2006-01-02"
+	DATE_LAYOUT_NAME      = "DATE_LAYOUT"
+	ERR_INVALID_DATE      = "invalid date"
+	ERR_INVALID_DATE_NAME = "ErrInvalidDate"
+
+	TIME_OF_DAY_NAME             = "TimeOfDay"
+	TIME_OF_DAY_RECEIVER_NAME    = "timeOfDay"
+	TIME_OF_DAY_LAYOUT           = "15:04:05"
+	TIME_OF_DAY_LAYOUT_NAME      = "TIME_OF_DAY_LAYOUT"
+	ERR_INVALID_TIME_OF_DAY      = "invalid time of day"
+	ERR_INVALID_TIME_OF_DAY_NAME = "ErrInvalidTimeOfDay"
+)
+
+// civilType is a calendar date or a wall clock time, without timezone.
+// Both are strings in their layout so they are compared, marshalled and validated as is.
+type civilType struct {
+	name     string
+	receiver string
+	layout   string
+	// name of the layout const
+	layoutName string
+	err        string
+	errName    string
+	// gorm column type
+	column string
+}
+
+var DATE_CIVIL_TYPE = &civilType{
+	name:       DATE_NAME,
+	receiver:   DATE_RECEIVER_NAME,
+	layout:     DATE_LAYOUT,
+	layoutName: DATE_LAYOUT_NAME,
+	err:        ERR_INVALID_DATE,
+	errName:    ERR_INVALID_DATE_NAME,
+	column:     "date",
+}
+
+var TIME_OF_DAY_CIVIL_TYPE = &civilType{
+	name:       TIME_OF_DAY_NAME,
+	receiver:   TIME_OF_DAY_RECEIVER_NAME,
+	layout:     TIME_OF_DAY_LAYOUT,
+	layoutName: TIME_OF_DAY_LAYOUT_NAME,
+	err:        ERR_INVALID_TIME_OF_DAY,
+	errName:    ERR_INVALID_TIME_OF_DAY_NAME,
+	column:     "time",
+}
+
+// getCivilType returns the civil type of t, nil when t is not a date or a time of day
+func getCivilType(t coredomaindefinition.Type) *civilType {
+	switch t {
+	case coredomaindefinition.PrimitiveTypeDate:
+		return DATE_CIVIL_TYPE
+	case coredomaindefinition.PrimitiveTypeTime:
+		return TIME_OF_DAY_CIVIL_TYPE
+	}
+	return nil
+}
+
+// GetCivilTimeGormTags returns the gorm column type of a date or a time of day field, nil for other types
+func GetCivilTimeGormTags(ctx context.Context, t coredomaindefinition.Type) []string {
+	civil := getCivilType(t)
+	if civil == nil {
+		return nil
+	}
+	return []string{"type:" + civil.column}
+}
+
+// GetCivilTimeValidationTags returns the validate tags checking the layout of a date or a time of day, diving into arrays
+func GetCivilTimeValidationTags(ctx context.Context, t coredomaindefinition.Type) []string {
+	tags := []string{}
+	if array, ok := t.(*coredomaindefinition.Array); ok {
+		t = array.Type
+		tags = append(tags, "dive")
+	}
+	civil := getCivilType(t)
+	if civil == nil {
+		return nil
+	}
+	return append(tags, "datetime="+civil.layout)
+}
+
+// elements returns the type, its layout, error and functions:
+// New<Name> parses a string, <Name>Of takes the civil part of a time.Time, Time converts back,
+// UnmarshalJSON rejects other layouts, Value and Scan store it in the database.
+func (civil *civilType) elements() []interface{} {
+	t := &model.TypeDefinition{
+		Name: civil.name,
+		Type: model.PrimitiveTypeString,
+	}
+	timeTime := &model.PkgReference{Pkg: consts.CommonPkgs["time"], Reference: &model.ExternalType{Type: "Time"}}
+
+	return []interface{}{
+		&model.Var{
+			Name:    civil.layoutName,
+			Type:    model.PrimitiveTypeString,
+			Value:   civil.layout,
+			IsConst: true,
+		},
+		&model.Var{
+			Name: civil.errName,
+			Type: model.PrimitiveTypeError,
+			Value: &model.PkgReference{
+				Pkg: consts.CommonPkgs["errors"], Reference: &model.ExternalType{Type: fmt.Sprintf(`New("%s")`, civil.err)},
+			},
+		},
+		t,
+		&model.Function{
+			Name:    "New" + civil.name,
+			Args:    []*model.Param{{Name: "value", Type: model.PrimitiveTypeString}},
+			Results: []*model.Param{{Type: t}, {Type: model.PrimitiveTypeError}},
+			Content: func() (string, []*model.GoPkg) {
+				str := fmt.Sprintf("if _, err := time.Parse(%s, value); err != nil {", civil.layoutName) + consts.LN
+				str += fmt.Sprintf(`return "", fmt.Errorf("%%w: %%s", %s, value)`, civil.errName) + consts.LN
+				str += "}" + consts.LN
+				str += fmt.Sprintf("return %s(value), nil", civil.name)
+				return str, []*model.GoPkg{consts.CommonPkgs["time"], consts.CommonPkgs["fmt"]}
+			},
+		},
+		&model.Function{
+			Name:    civil.name + "Of",
+			Args:    []*model.Param{{Name: "t", Type: timeTime}},
+			Results: []*model.Param{{Type: t}},
+			Content: func() (string, []*model.GoPkg) {
+				return fmt.Sprintf("return %s(t.Format(%s))", civil.name, civil.layoutName), nil
+			},
+		},
+		&model.Function{
+			On:      t,
+			OnName:  civil.receiver,
+			Name:    "Time",
+			Results: []*model.Param{{Type: timeTime}},
+			Content: func() (string, []*model.GoPkg) {
+				str := "// in UTC, zero when invalid" + consts.LN
+				str += fmt.Sprintf("t, _ := time.Parse(%s, string(%s))", civil.layoutName, civil.receiver) + consts.LN
+				str += "return t"
+				return str, nil
+			},
+		},
+		&model.Function{
+			On:      &model.PointerType{Type: t},
+			OnName:  civil.receiver,
+			Name:    "UnmarshalJSON",
+			Args:    []*model.Param{{Name: "data", Type: model.PrimitiveTypeBytes}},
+			Results: []*model.Param{{Type: model.PrimitiveTypeError}},
+			Content: func() (string, []*model.GoPkg) {
+				str := "var value string" + consts.LN
+				str += "if err := json.Unmarshal(data, &value); err != nil {" + consts.LN
+				str += "return err" + consts.LN
+				str += "}" + consts.LN
+				str += `if value == "" {` + consts.LN
+				str += fmt.Sprintf(`*%s = ""`, civil.receiver) + consts.LN
+				str += "return nil" + consts.LN
+				str += "}" + consts.LN
+				str += fmt.Sprintf("parsed, err := New%s(value)", civil.name) + consts.LN
+				str += "if err != nil {" + consts.LN
+				str += "return err" + consts.LN
+				str += "}" + consts.LN
+				str += fmt.Sprintf("*%s = parsed", civil.receiver) + consts.LN
+				str += "return nil"
+				return str, []*model.GoPkg{consts.CommonPkgs["json"]}
+			},
+		},
+		&model.Function{
+			On:      t,
+			OnName:  civil.receiver,
+			Name:    "Value",
+			Results: []*model.Param{{Type: driverValue}, {Type: model.PrimitiveTypeError}},
+			Content: func() (string, []*model.GoPkg) {
+				str := fmt.Sprintf(`if %s == "" {`, civil.receiver) + consts.LN
+				str += "return nil, nil" + consts.LN
+				str += "}" + consts.LN
+				str += fmt.Sprintf("return string(%s), nil", civil.receiver)
+				return str, nil
+			},
+		},
+		&model.Function{
+			On:      &model.PointerType{Type: t},
+			OnName:  civil.receiver,
+			Name:    "Scan",
+			Args:    []*model.Param{{Name: "value", Type: model.PrimitiveTypeInterface}},
+			Results: []*model.Param{{Type: model.PrimitiveTypeError}},
+			Content: func() (string, []*model.GoPkg) {
+				str := "switch v := value.(type) {" + consts.LN
+				str += "case nil:" + consts.LN
+				str += fmt.Sprintf(`*%s = ""`, civil.receiver) + consts.LN
+				str += "case time.Time:" + consts.LN
+				str += fmt.Sprintf("*%s = %sOf(v)", civil.receiver, civil.name) + consts.LN
+				str += "case []byte:" + consts.LN
+				str += fmt.Sprintf("return %s.Scan(string(v))", civil.receiver) + consts.LN
+				str += "case string:" + consts.LN
+				str += "// drivers may add fractional seconds, a time or a timezone after the layout" + consts.LN
+				str += fmt.Sprintf("if len(v) < len(%s) {", civil.layoutName) + consts.LN
+				str += fmt.Sprintf(`return fmt.Errorf("%%w: %%s", %s, v)`, civil.errName) + consts.LN
+				str += "}" + consts.LN
+				str += fmt.Sprintf("parsed, err := New%s(v[:len(%s)])", civil.name, civil.layoutName) + consts.LN
+				str += "if err != nil {" + consts.LN
+				str += "return err" + consts.LN
+				str += "}" + consts.LN
+				str += fmt.Sprintf("*%s = parsed", civil.receiver) + consts.LN
+				str += "default:" + consts.LN
+				str += fmt.Sprintf(`return fmt.Errorf("%%w: %%v", %s, value)`, civil.errName) + consts.LN
+				str += "}" + consts.LN
+				str += "return nil"
+				return str, []*model.GoPkg{consts.CommonPkgs["time"], consts.CommonPkgs["fmt"]}
+			},
+		},
+	}
+}
+
+// addCivilTime adds the Date and TimeOfDay types to the consts package when used
+func (builder *domainBuilder) addCivilTime(ctx context.Context) {
+	if builder.err != nil {
+		return
+	}
+
+	for _, t := range []coredomaindefinition.PrimitiveType{coredomaindefinition.PrimitiveTypeDate, coredomaindefinition.PrimitiveTypeTime} {
+		if !builder.usesPrimitiveType(ctx, t) {
+			continue
+		}
+
+		civil := getCivilType(t)
+		builder.Domain.Files = append(builder.Domain.Files, &model.File{
+			Name:     civil.name,
+			Pkg:      builder.GetConstsPackage(),
+			Elements: civil.elements(),
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package domainbuilder
+
+import (
+	"testing"
+
+	"github.com/cleogithub/golem/coredomaindefinition"
+)
+
+func TestCivilTimeGeneration(t *testing.T) {
+	shop := coredomaindefinition.NewModel("shop")
+	shop.Fields = []*coredomaindefinition.Field{
+		{Name: "openedOn", Type: coredomaindefinition.PrimitiveTypeDate},
+		{Name: "opensAt", Type: coredomaindefinition.PrimitiveTypeTime, Optional: true},
+	}
+
+	files := generate(t, withCRUD(newTestDomain(shop), shop))
+
+	assertContains(t, files.goFile(t, "domain/consts/date"), "const DATE_LAYOUT string = \"2006-01-02\"", "type Date string")
+	assertContains(t, files.goFile(t, "domain/consts/timeOfDay"), "type TimeOfDay string")
+	assertContains(t, files.goFile(t, "domain/model/shop"),
+		"OpenedOn  consts.Date       `json:\"openedOn\"`",
+		"OpensAt   *consts.TimeOfDay `json:\"opensAt,omitempty\"`",
+	)
+	// civil values are DATE and TIME columns, not timestamps
+	assertContains(t, files.goFile(t, "adapter/repository/gormadapter/shop"),
+		"OpenedOn  consts.Date       `gorm:\"column:opened_on;type:date\"`",
+		"OpensAt   *consts.TimeOfDay `gorm:\"column:opens_at;type:time\"`",
+	)
+	assertContains(t, files.goFile(t, "domain/usecase/structs"),
+		"`json:\"openedOn\" validate:\"omitempty,datetime=2006-01-02\"`",
+		"`json:\"opensAt,omitempty\" validate:\"omitempty,datetime=15:04:05\"`",
+	)
+	assertContains(t, files.jsFile(t, "entities"), "\topenedOn // string\n", "\topensAt // string\n")
+}
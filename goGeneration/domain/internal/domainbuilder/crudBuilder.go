@@ -56,6 +56,8 @@ type CRUDBuilder struct {
 
 	createValidation string
 	updateValidation string
+	// checks of the model validations on several fields, run on the entity once defaults are set
+	fieldsValidation string
 
 	requestFieldToField string
 
@@ -70,6 +72,7 @@ func NewCRUDBuilder(ctx context.Context, domainBuilder *domainBuilder, definitio
 	}
 
 	builder.addValidationChecks(ctx)
+	builder.addFieldsValidationChecks(ctx)
 	builder.addModelRequestFieldToField(ctx)
 
 	if definition.Get.Active {
@@ -138,6 +141,21 @@ func (builder *CRUDBuilder) addValidationChecks(ctx context.Context) {
 
 }
 
+func (builder *CRUDBuilder) addFieldsValidationChecks(ctx context.Context) {
+	if builder.err != nil {
+		return
+	}
+
+	for _, validation := range builder.definition.On.Validations {
+		str, err := GetFieldsValidationCheck(ctx, validation, builder.definition.On.Fields, "entity", CRUD_IMPL_STUCT_NAME)
+		if err != nil {
+			builder.err = err
+			return
+		}
+		builder.fieldsValidation += str
+	}
+}
+
 func (builder *CRUDBuilder) addUniqueCheck(ctx context.Context, field *coredomaindefinition.Field) {
 	if builder.err != nil {
 		return
@@ -171,7 +189,7 @@ func (builder *CRUDBuilder) addUniqueCheck(ctx context.Context, field *coredomai
 	str += "return nil, err" + consts.LN
 	str += fmt.Sprintf("} else if err != %s.%s {", repoAlias, REPOSITORY_ERROR_NOT_FOUND.Name) + consts.LN
 	str += "// should get ErrNotFound" + consts.LN
-	str += fmt.Sprintf(`return nil, %s.%s.%s(ctx, "%s")`, CRUD_IMPL_STUCT_NAME, VALIDATOR_NAME, VALIDATOR_NEW_UNIQUE_ERROR_METHOD_NAME, GetFieldName(ctx, field.Name)) + consts.LN
+	str += fmt.Sprintf(`return nil, %s.%s.%s(ctx, "%s")`, CRUD_IMPL_STUCT_NAME, VALIDATOR_NAME, VALIDATOR_NEW_UNIQUE_ERROR_METHOD_NAME, field.Name) + consts.LN
 	str += "}" + consts.LN
 
 	builder.createValidation += str
@@ -216,7 +234,7 @@ func (builder *CRUDBuilder) addUniqueInCheck(ctx context.Context, field *coredom
 	str += "return nil, err" + consts.LN
 	str += fmt.Sprintf("} else if err != %s.%s {", repoAlias, REPOSITORY_ERROR_NOT_FOUND.Name) + consts.LN
 	str += "// should get ErrNotFound" + consts.LN
-	str += fmt.Sprintf(`return nil, %s.%s.%s(ctx, "%s")`, CRUD_IMPL_STUCT_NAME, VALIDATOR_NAME, VALIDATOR_NEW_UNIQUE_ERROR_METHOD_NAME, GetFieldName(ctx, field.Name)) + consts.LN
+	str += fmt.Sprintf(`return nil, %s.%s.%s(ctx, "%s")`, CRUD_IMPL_STUCT_NAME, VALIDATOR_NAME, VALIDATOR_NEW_UNIQUE_ERROR_METHOD_NAME, field.Name) + consts.LN
 	str += "}" + consts.LN
 
 	builder.createValidation += str
@@ -243,28 +261,15 @@ func (builder *CRUDBuilder) WithRelation(ctx context.Context, definition *coredo
 	}
 
 	if !IsRelationMultiple(ctx, builder.definition.On, definition) {
-		tags := []string{"uuid"}
 		optionnal, err := IsRelationOptionnal(ctx, builder.definition.On, definition)
 		if err != nil {
 			builder.err = err
 			return
 		}
-		if !optionnal {
-			tags = append(tags, "required")
-		}
-		field := &model.Field{
-			Name: GetSingleRelationIdName(ctx, to),
-			Type: model.PrimitiveTypeString,
-			Tags: []*model.Tag{
-				{
-					Name:   "json",
-					Values: []string{stringtool.LowerFirstLetter(GetSingleRelationIdName(ctx, to))},
-				},
-				{
-					Name:   "validate",
-					Values: tags,
-				},
-			},
+		field := builder.getIdField(ctx, GetSingleRelationIdName(ctx, to), to)
+		if optionnal {
+			// an optional reference is only checked when provided
+			field.Tags[1].Values[0] = "omitempty"
 		}
 		if builder.createRequest != nil {
 			builder.createRequest.Fields = append(builder.createRequest.Fields, field)
@@ -276,7 +281,7 @@ func (builder *CRUDBuilder) WithRelation(ctx context.Context, definition *coredo
 		repoAlias := builder.domainBuilder.GetRepositoryPackage().Alias
 		str := fmt.Sprintf("// validate relation %s", GetModelName(ctx, to)) + consts.LN
 		if optionnal {
-			str += fmt.Sprintf(`if %s.%s != "" {`, REQUEST_PARAM_NAME, GetSingleRelationIdName(ctx, to)) + consts.LN
+			str += fmt.Sprintf(`if %s.%s != %s {`, REQUEST_PARAM_NAME, GetSingleRelationIdName(ctx, to), builder.domainBuilder.GetIDZeroValue(ctx, to)) + consts.LN
 		}
 		str += fmt.Sprintf("if _, err := %s.%s.%s(", CRUD_IMPL_STUCT_NAME, CRUD_IMPL_REPO_NAME, GetRepositoryGetMethod(ctx, to)) + consts.LN
 		str += "ctx," + consts.LN
@@ -290,7 +295,7 @@ func (builder *CRUDBuilder) WithRelation(ctx context.Context, definition *coredo
 		str += ");  err != nil {" + consts.LN
 		// str += "" + consts.LN
 		str += fmt.Sprintf("if err == %s.%s {", repoAlias, REPOSITORY_ERROR_NOT_FOUND.Name) + consts.LN
-		str += fmt.Sprintf(`return nil, %s.%s.%s(ctx, "%s")`, CRUD_IMPL_STUCT_NAME, VALIDATOR_NAME, VALIDATOR_NEW_REFERENCE_ERROR_METHOD_NAME, GetSingleRelationIdName(ctx, to)) + consts.LN
+		str += fmt.Sprintf(`return nil, %s.%s.%s(ctx, "%s")`, CRUD_IMPL_STUCT_NAME, VALIDATOR_NAME, VALIDATOR_NEW_REFERENCE_ERROR_METHOD_NAME, stringtool.LowerFirstLetter(GetSingleRelationIdName(ctx, to))) + consts.LN
 		str += "}" + consts.LN
 		str += "return nil, err" + consts.LN
 		str += "}" + consts.LN
@@ -611,14 +616,40 @@ func (builder *CRUDBuilder) addCreate(ctx context.Context) {
 			},
 		},
 		Content: func() (content string, requiredPkg []*model.GoPkg) {
-			str := builder.createValidation
-
-			str += fmt.Sprintf("uuid := %s.NewString()", consts.CommonPkgs["uuid"].Alias) + consts.LN
+			idGeneration, idPkgs := builder.domainBuilder.GetIDGeneration(ctx, builder.definition.On, "id")
+			str := idGeneration
+
+			pkgs := append(idPkgs, builder.domainBuilder.GetModelPackage())
+			defaults, usesNow := "", false
+			for _, f := range builder.domainBuilder.GetDefaultModelFields(ctx, builder.definition.On) {
+				value, now, p := builder.domainBuilder.GetDefaultValue(ctx, f)
+				if value != "" {
+					defaults += fmt.Sprintf("%s: %s,", GetFieldName(ctx, f.Name), value) + consts.LN
+					usesNow, pkgs = usesNow || now, append(pkgs, p...)
+				}
+			}
+			assignments := ""
+			for _, f := range builder.definition.On.Fields {
+				assignment, now, p := builder.domainBuilder.GetDefaultAssignment(ctx, f, "entity."+GetFieldName(ctx, f.Name))
+				assignments += assignment
+				usesNow, pkgs = usesNow || now, append(pkgs, p...)
+			}
+			if usesNow {
+				str += fmt.Sprintf("%s := %s.Now()", NOW_VAR_NAME, consts.CommonPkgs["time"].Alias) + consts.LN
+				pkgs = append(pkgs, consts.CommonPkgs["time"])
+			}
 
 			str += fmt.Sprintf("entity := &%s.%s{", builder.domainBuilder.GetModelPackage().Alias, GetModelName(ctx, builder.definition.On)) + consts.LN
-			str += fmt.Sprintf("%s: uuid,", consts.ID) + consts.LN
+			if idGeneration != "" {
+				str += fmt.Sprintf("%s: id,", consts.ID) + consts.LN
+			}
+			str += defaults
 			str += builder.requestFieldToField
 			str += "}" + consts.LN
+			str += assignments
+			// invalid fields are reported before any repository call
+			str += builder.fieldsValidation
+			str += builder.createValidation
 
 			str += fmt.Sprintf("entity, err := %s.%s.%s(ctx, entity)", CRUD_IMPL_STUCT_NAME, CRUD_IMPL_REPO_NAME, GetRepositoryCreateMethod(ctx, builder.definition.On)) + consts.LN
 			str += "if err != nil {" + consts.LN
@@ -626,10 +657,7 @@ func (builder *CRUDBuilder) addCreate(ctx context.Context) {
 			str += "}" + consts.LN
 
 			str += fmt.Sprintf("return &%s{%s: entity}, nil", GetUsecaseResponseName(ctx, action), GetModelName(ctx, builder.definition.On)) + consts.LN
-			return str, []*model.GoPkg{
-				consts.CommonPkgs["uuid"],
-				builder.domainBuilder.GetModelPackage(),
-			}
+			return str, pkgs
 		},
 	}
 	builder.Methods = append(builder.Methods, builder.create)
@@ -696,23 +724,28 @@ func (builder *CRUDBuilder) addUpdate(ctx context.Context) {
 			},
 		},
 		Content: func() (content string, requiredPkg []*model.GoPkg) {
-			str := builder.updateValidation
-
-			str += fmt.Sprintf("entity := &%s.%s{", builder.domainBuilder.GetModelPackage().Alias, GetModelName(ctx, builder.definition.On)) + consts.LN
+			pkgs := []*model.GoPkg{builder.domainBuilder.GetModelPackage()}
+			str := fmt.Sprintf("entity := &%s.%s{", builder.domainBuilder.GetModelPackage().Alias, GetModelName(ctx, builder.definition.On)) + consts.LN
 			str += fmt.Sprintf("%s: %s.%s,", consts.ID, REQUEST_PARAM_NAME, consts.ID) + consts.LN
+			for _, f := range builder.domainBuilder.GetDefaultModelFields(ctx, builder.definition.On) {
+				if GetFieldName(ctx, f.Name) == consts.UPDATED_AT {
+					str += fmt.Sprintf("%s: %s.Now(),", consts.UPDATED_AT, consts.CommonPkgs["time"].Alias) + consts.LN
+					pkgs = append(pkgs, consts.CommonPkgs["time"])
+				}
+			}
 			str += builder.requestFieldToField
 			str += "}" + consts.LN
+			// invalid fields are reported before any repository call
+			str += builder.fieldsValidation
+			str += builder.updateValidation
 
-			str += fmt.Sprintf("entity, err := %s.%s.%s(ctx, entity)", CRUD_IMPL_STUCT_NAME, CRUD_IMPL_REPO_NAME, GetRepositoryCreateMethod(ctx, builder.definition.On)) + consts.LN
+			str += fmt.Sprintf("entity, err := %s.%s.%s(ctx, entity)", CRUD_IMPL_STUCT_NAME, CRUD_IMPL_REPO_NAME, GetRepositoryUpdateMethod(ctx, builder.definition.On)) + consts.LN
 			str += "if err != nil {" + consts.LN
 			str += "return nil, err" + consts.LN
 			str += "}" + consts.LN
 
 			str += fmt.Sprintf("return &%s{%s: entity}, nil", GetUsecaseResponseName(ctx, action), GetModelName(ctx, builder.definition.On)) + consts.LN
-			return str, []*model.GoPkg{
-				consts.CommonPkgs["uuid"],
-				builder.domainBuilder.GetModelPackage(),
-			}
+			return str, pkgs
 		},
 	}
 	builder.Methods = append(builder.Methods, builder.update)
@@ -726,20 +759,7 @@ func (builder *CRUDBuilder) addDelete(ctx context.Context) {
 	builder.deleteRequest = &model.Struct{
 		Name: GetUsecaseRequestName(ctx, action),
 		Fields: []*model.Field{
-			{
-				Name: "Id",
-				Type: model.PrimitiveTypeString,
-				Tags: []*model.Tag{
-					{
-						Name:   "json",
-						Values: []string{"id"},
-					},
-					{
-						Name:   "validate",
-						Values: []string{"required", "uuid"},
-					},
-				},
-			},
+			builder.getIdField(ctx, "Id", builder.definition.On),
 		},
 	}
 	builder.Structs = append(builder.Structs, builder.deleteRequest)
@@ -828,34 +848,8 @@ func (builder *CRUDBuilder) addRelationCRUDAdd(ctx context.Context, relation *co
 	request := &model.Struct{
 		Name: GetUsecaseRequestName(ctx, action),
 		Fields: []*model.Field{
-			{
-				Name: GetSingleRelationIdName(ctx, from),
-				Type: model.PrimitiveTypeString,
-				Tags: []*model.Tag{
-					{
-						Name:   "json",
-						Values: []string{stringtool.LowerFirstLetter(GetSingleRelationIdName(ctx, from))},
-					},
-					{
-						Name:   "validate",
-						Values: []string{"required", "uuid"},
-					},
-				},
-			},
-			{
-				Name: GetSingleRelationIdName(ctx, to),
-				Type: model.PrimitiveTypeString,
-				Tags: []*model.Tag{
-					{
-						Name:   "json",
-						Values: []string{stringtool.LowerFirstLetter(GetSingleRelationIdName(ctx, to))},
-					},
-					{
-						Name:   "validate",
-						Values: []string{"required", "uuid"},
-					},
-				},
-			},
+			builder.getIdField(ctx, GetSingleRelationIdName(ctx, from), from),
+			builder.getIdField(ctx, GetSingleRelationIdName(ctx, to), to),
 		},
 	}
 	builder.Structs = append(builder.Structs, request)
@@ -920,34 +914,8 @@ func (builder *CRUDBuilder) addRelationCRUDRemove(ctx context.Context, relation
 	request := &model.Struct{
 		Name: GetUsecaseRequestName(ctx, action),
 		Fields: []*model.Field{
-			{
-				Name: GetSingleRelationIdName(ctx, from),
-				Type: model.PrimitiveTypeString,
-				Tags: []*model.Tag{
-					{
-						Name:   "json",
-						Values: []string{stringtool.LowerFirstLetter(GetSingleRelationIdName(ctx, from))},
-					},
-					{
-						Name:   "validate",
-						Values: []string{"required", "uuid"},
-					},
-				},
-			},
-			{
-				Name: GetSingleRelationIdName(ctx, to),
-				Type: model.PrimitiveTypeString,
-				Tags: []*model.Tag{
-					{
-						Name:   "json",
-						Values: []string{stringtool.LowerFirstLetter(GetSingleRelationIdName(ctx, to))},
-					},
-					{
-						Name:   "validate",
-						Values: []string{"required", "uuid"},
-					},
-				},
-			},
+			builder.getIdField(ctx, GetSingleRelationIdName(ctx, from), from),
+			builder.getIdField(ctx, GetSingleRelationIdName(ctx, to), to),
 		},
 	}
 	builder.Structs = append(builder.Structs, request)
@@ -1013,20 +981,7 @@ func (builder *CRUDBuilder) addRelationCRUDList(ctx context.Context, relation *c
 	request := &model.Struct{
 		Name: GetUsecaseRequestName(ctx, action),
 		Fields: []*model.Field{
-			{
-				Name: GetSingleRelationIdName(ctx, from),
-				Type: model.PrimitiveTypeString,
-				Tags: []*model.Tag{
-					{
-						Name:   "json",
-						Values: []string{stringtool.LowerFirstLetter(GetSingleRelationIdName(ctx, from))},
-					},
-					{
-						Name:   "validate",
-						Values: []string{"required", "uuid"},
-					},
-				},
-			},
+			builder.getIdField(ctx, GetSingleRelationIdName(ctx, from), from),
 		},
 	}
 	builder.Structs = append(builder.Structs, request)
@@ -1124,20 +1079,25 @@ func (builder *CRUDBuilder) addIdFieldToStruct(ctx context.Context, request *mod
 	if builder.err != nil {
 		return
 	}
-	request.Fields = append(request.Fields, &model.Field{
-		Name: "Id",
-		Type: model.PrimitiveTypeString,
+	request.Fields = append(request.Fields, builder.getIdField(ctx, "Id", builder.definition.On))
+}
+
+// getIdField returns a required request field holding a primary key of m
+func (builder *CRUDBuilder) getIdField(ctx context.Context, name string, m *coredomaindefinition.Model) *model.Field {
+	return &model.Field{
+		Name: name,
+		Type: builder.domainBuilder.GetIDType(ctx, m),
 		Tags: []*model.Tag{
 			{
 				Name:   "json",
-				Values: []string{"id"},
+				Values: []string{stringtool.LowerFirstLetter(name)},
 			},
 			{
 				Name:   "validate",
-				Values: []string{"required", "uuid"},
+				Values: append([]string{"required"}, builder.domainBuilder.GetIDValidationTags(ctx, m)...),
 			},
 		},
-	})
+	}
 }
 
 func (builder *CRUDBuilder) addDefaultFieldsToModificationStruct(ctx context.Context, request *model.Struct) {
@@ -1162,7 +1122,7 @@ func (builder *CRUDBuilder) addDefaultFieldsToModificationStruct(ctx context.Con
 			builder.err = merror.Stack(err)
 			return
 		}
-		validationTags, err := GetValidationTags(ctx, f.Validations)
+		validationTags, err := GetValidationTags(ctx, f)
 		if err != nil {
 			builder.err = merror.Stack(err)
 			return
@@ -1186,20 +1146,7 @@ func (builder *CRUDBuilder) buildGetStructs(ctx context.Context) {
 		builder.getRequest = &model.Struct{
 			Name: GetUsecaseRequestName(ctx, action),
 			Fields: []*model.Field{
-				{
-					Name: "Id",
-					Type: model.PrimitiveTypeString,
-					Tags: []*model.Tag{
-						{
-							Name:   "json",
-							Values: []string{"id"},
-						},
-						{
-							Name:   "validate",
-							Values: []string{"required", "uuid"},
-						},
-					},
-				},
+				builder.getIdField(ctx, "Id", builder.definition.On),
 			},
 		}
 		builder.Structs = append(builder.Structs, builder.getRequest)
@@ -0,0 +1,238 @@
+package domainbuilder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cleogithub/golem/coredomaindefinition"
+	"github.com/cleogithub/golem/goGeneration/domain/consts"
+	"github.com/cleogithub/golem/goGeneration/domain/model"
+)
+
+const (
+	DECIMAL_NAME             = "Decimal"
+	DECIMAL_RECEIVER_NAME    = "decimal"
+	ERR_INVALID_DECIMAL_NAME = "ErrInvalidDecimal"
+	ERR_INVALID_DECIMAL      = "invalid decimal"
+	DECIMAL_PATTERN_NAME     = "decimalPattern"
+
+	MONEY_NAME = "Money"
+	// money amounts are stored with the precision of the SQL standard money types
+	MONEY_PRECISION = 19
+	MONEY_SCALE     = 4
+)
+
+var DECIMAL = &model.TypeDefinition{
+	Name: DECIMAL_NAME,
+	Type: model.PrimitiveTypeString,
+}
+
+var bigRat = &model.PkgReference{
+	Pkg:       &model.GoPkg{Alias: "big", ShortName: "big", FullName: "math/big"},
+	Reference: &model.ExternalType{Type: "Rat"},
+}
+
+var driverValue = &model.PkgReference{
+	Pkg:       &model.GoPkg{Alias: "driver", ShortName: "driver", FullName: "database/sql/driver"},
+	Reference: &model.ExternalType{Type: "Value"},
+}
+
+// DECIMAL_FUNCTIONS are the constructors and methods of Decimal.
+// Decimal keeps the exact text of the number, arithmetic goes through math/big.
+var DECIMAL_FUNCTIONS = []*model.Function{
+	{
+		Name:    "New" + DECIMAL_NAME,
+		Args:    []*model.Param{{Name: "value", Type: model.PrimitiveTypeString}},
+		Results: []*model.Param{{Type: DECIMAL}, {Type: model.PrimitiveTypeError}},
+		Content: func() (string, []*model.GoPkg) {
+			str := fmt.Sprintf("if !%s.MatchString(value) {", DECIMAL_PATTERN_NAME) + consts.LN
+			str += fmt.Sprintf(`return "", fmt.Errorf("%%w: %%s", %s, value)`, ERR_INVALID_DECIMAL_NAME) + consts.LN
+			str += "}" + consts.LN
+			str += fmt.Sprintf("return %s(value), nil", DECIMAL_NAME)
+			return str, []*model.GoPkg{consts.CommonPkgs["fmt"]}
+		},
+	},
+	{
+		Name:    DECIMAL_NAME + "FromRat",
+		Args:    []*model.Param{{Name: "rat", Type: &model.PointerType{Type: bigRat}}, {Name: "scale", Type: model.PrimitiveTypeInt}},
+		Results: []*model.Param{{Type: DECIMAL}},
+		Content: func() (string, []*model.GoPkg) {
+			return fmt.Sprintf("return %s(rat.FloatString(int(scale)))", DECIMAL_NAME), nil
+		},
+	},
+	{
+		On:      DECIMAL,
+		OnName:  DECIMAL_RECEIVER_NAME,
+		Name:    "Rat",
+		Results: []*model.Param{{Type: &model.PointerType{Type: bigRat}}},
+		Content: func() (string, []*model.GoPkg) {
+			str := fmt.Sprintf("rat, ok := new(big.Rat).SetString(string(%s))", DECIMAL_RECEIVER_NAME) + consts.LN
+			str += "if !ok {" + consts.LN
+			str += "return new(big.Rat)" + consts.LN
+			str += "}" + consts.LN
+			str += "return rat"
+			return str, nil
+		},
+	},
+	{
+		On:      DECIMAL,
+		OnName:  DECIMAL_RECEIVER_NAME,
+		Name:    "Value",
+		Results: []*model.Param{{Type: driverValue}, {Type: model.PrimitiveTypeError}},
+		Content: func() (string, []*model.GoPkg) {
+			str := fmt.Sprintf(`if %s == "" {`, DECIMAL_RECEIVER_NAME) + consts.LN
+			str += `return "0", nil` + consts.LN
+			str += "}" + consts.LN
+			str += fmt.Sprintf("if _, err := New%s(string(%s)); err != nil {", DECIMAL_NAME, DECIMAL_RECEIVER_NAME) + consts.LN
+			str += "return nil, err" + consts.LN
+			str += "}" + consts.LN
+			str += fmt.Sprintf("return string(%s), nil", DECIMAL_RECEIVER_NAME)
+			return str, nil
+		},
+	},
+	{
+		On:      &model.PointerType{Type: DECIMAL},
+		OnName:  DECIMAL_RECEIVER_NAME,
+		Name:    "Scan",
+		Args:    []*model.Param{{Name: "value", Type: model.PrimitiveTypeInterface}},
+		Results: []*model.Param{{Type: model.PrimitiveTypeError}},
+		Content: func() (string, []*model.GoPkg) {
+			str := "switch v := value.(type) {" + consts.LN
+			str += "case nil:" + consts.LN
+			str += fmt.Sprintf(`*%s = ""`, DECIMAL_RECEIVER_NAME) + consts.LN
+			str += "case []byte:" + consts.LN
+			str += fmt.Sprintf("*%s = %s(v)", DECIMAL_RECEIVER_NAME, DECIMAL_NAME) + consts.LN
+			str += "case string:" + consts.LN
+			str += fmt.Sprintf("*%s = %s(v)", DECIMAL_RECEIVER_NAME, DECIMAL_NAME) + consts.LN
+			str += "case int64:" + consts.LN
+			str += fmt.Sprintf("*%s = %s(strconv.FormatInt(v, 10))", DECIMAL_RECEIVER_NAME, DECIMAL_NAME) + consts.LN
+			str += "case float64:" + consts.LN
+			str += fmt.Sprintf("*%s = %s(strconv.FormatFloat(v, 'f', -1, 64))", DECIMAL_RECEIVER_NAME, DECIMAL_NAME) + consts.LN
+			str += "default:" + consts.LN
+			str += fmt.Sprintf(`return fmt.Errorf("%%w: %%v", %s, value)`, ERR_INVALID_DECIMAL_NAME) + consts.LN
+			str += "}" + consts.LN
+			str += "return nil"
+			return str, []*model.GoPkg{{Alias: "strconv", ShortName: "strconv", FullName: "strconv"}, consts.CommonPkgs["fmt"]}
+		},
+	},
+}
+
+// MONEY is an exact amount with its ISO 4217 currency, embedded in the table of the models using it
+var MONEY = &model.Struct{
+	Name: MONEY_NAME,
+	Fields: []*model.Field{
+		{
+			Name:     "Amount",
+			Type:     DECIMAL,
+			JsonName: "amount",
+			Tags: []*model.Tag{
+				{Name: "json", Values: []string{"amount"}},
+				{Name: "gorm", Values: []string{fmt.Sprintf("type:numeric(%d,%d)", MONEY_PRECISION, MONEY_SCALE)}},
+				{Name: "validate", Values: []string{"numeric"}},
+			},
+		},
+		{
+			Name:     "Currency",
+			Type:     model.PrimitiveTypeString,
+			JsonName: "currency",
+			Tags: []*model.Tag{
+				{Name: "json", Values: []string{"currency"}},
+				{Name: "gorm", Values: []string{"size:3"}},
+				{Name: "validate", Values: []string{"iso4217"}},
+			},
+		},
+	},
+}
+
+// addDecimal adds the Decimal type, and the Money struct when used, to the model package
+func (builder *domainBuilder) addDecimal(ctx context.Context) {
+	if builder.err != nil {
+		return
+	}
+
+	usesMoney := builder.usesPrimitiveType(ctx, coredomaindefinition.PrimitiveTypeMoney)
+	if !usesMoney && !builder.usesPrimitiveType(ctx, coredomaindefinition.PrimitiveTypeDecimal) {
+		return
+	}
+
+	elements := []interface{}{
+		&model.Var{
+			Name: ERR_INVALID_DECIMAL_NAME,
+			Type: model.PrimitiveTypeError,
+			Value: &model.PkgReference{
+				Pkg: consts.CommonPkgs["errors"], Reference: &model.ExternalType{Type: fmt.Sprintf(`New("%s")`, ERR_INVALID_DECIMAL)},
+			},
+		},
+		// big.Rat also parses exponents, hexadecimal floats and underscores
+		&model.Var{
+			Name: DECIMAL_PATTERN_NAME,
+			Value: &model.PkgReference{
+				Pkg: consts.CommonPkgs["regexp"], Reference: &model.ExternalType{Type: fmt.Sprintf("MustCompile(`%s`)", coredomaindefinition.DECIMAL_PATTERN)},
+			},
+		},
+		DECIMAL,
+	}
+	for _, function := range DECIMAL_FUNCTIONS {
+		elements = append(elements, function)
+	}
+	if usesMoney {
+		elements = append(elements, MONEY)
+	}
+
+	builder.Domain.Files = append(builder.Domain.Files, &model.File{
+		Name:     DECIMAL_NAME,
+		Pkg:      builder.GetModelPackage(),
+		Elements: elements,
+	})
+}
+
+// usesPrimitiveType reports whether a field of a model or a value object, or a usecase param, is of type t or an array or a map of t
+func (builder *domainBuilder) usesPrimitiveType(ctx context.Context, t coredomaindefinition.PrimitiveType) bool {
+	types := []coredomaindefinition.Type{}
+	for _, m := range builder.Definition.Models {
+		for _, f := range m.Fields {
+			types = append(types, f.Type)
+		}
+	}
+	for _, vo := range builder.Definition.ValueObjects {
+		for _, f := range vo.Fields {
+			types = append(types, f.Type)
+		}
+	}
+	for _, usecase := range builder.Definition.Usecases {
+		for _, p := range usecase.Args {
+			types = append(types, p.Type)
+		}
+		for _, p := range usecase.Results {
+			types = append(types, p.Type)
+		}
+	}
+
+	for len(types) > 0 {
+		ty := types[0]
+		types = types[1:]
+		switch ty := ty.(type) {
+		case *coredomaindefinition.Array:
+			types = append(types, ty.Type)
+		case *coredomaindefinition.Map:
+			types = append(types, ty.Key, ty.Value)
+		default:
+			if ty == t {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetDecimalColumnType returns the numeric column type of a decimal field
+func GetDecimalColumnType(ctx context.Context, field *coredomaindefinition.Field) string {
+	precision, scale := field.Precision, field.Scale
+	if precision == 0 {
+		precision = coredomaindefinition.DEFAULT_DECIMAL_PRECISION
+		if scale == 0 {
+			scale = coredomaindefinition.DEFAULT_DECIMAL_SCALE
+		}
+	}
+	return fmt.Sprintf("numeric(%d,%d)", precision, scale)
+}
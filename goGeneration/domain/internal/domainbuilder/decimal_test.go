@@ -0,0 +1,63 @@
+package domainbuilder
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/cleogithub/golem/coredomaindefinition"
+)
+
+func TestDecimalGeneration(t *testing.T) {
+	shop := coredomaindefinition.NewModel("shop")
+	shop.Fields = []*coredomaindefinition.Field{
+		{Name: "rate", Type: coredomaindefinition.PrimitiveTypeDecimal, Precision: 10, Scale: 2},
+		{Name: "total", Type: coredomaindefinition.PrimitiveTypeMoney},
+		{Name: "price", Type: coredomaindefinition.PrimitiveTypeMoney, Optional: true},
+	}
+
+	files := generate(t, withCRUD(newTestDomain(shop), shop))
+
+	assertContains(t, files.goFile(t, "domain/model/decimal"),
+		"type Decimal string",
+		"func NewDecimal(value string) (Decimal, error) {",
+		"type Money struct {\n\tAmount   Decimal `json:\"amount\" gorm:\"type:numeric(19,4)\" validate:\"numeric\"`\n\tCurrency string  `json:\"currency\" gorm:\"size:3\" validate:\"iso4217\"`\n}",
+	)
+	assertContains(t, files.goFile(t, "adapter/repository/gormadapter/shop"),
+		"Rate      model.Decimal   `gorm:\"column:rate;type:numeric(10,2)\"`",
+		"Total     model.Money     `gorm:\"embedded;embeddedPrefix:total_\"`",
+		"Price     *model.Money    `gorm:\"embedded;embeddedPrefix:price_\"`",
+	)
+	assertContains(t, files.goFile(t, "domain/usecase/structs"),
+		"Rate  model.Decimal `json:\"rate\" validate:\"omitempty,numeric\"`",
+		"Total model.Money   `json:\"total\" validate:\"omitempty\"`",
+		"Price *model.Money  `json:\"price,omitempty\" validate:\"omitempty\"`",
+	)
+}
+
+func TestMoneyValidationTags(t *testing.T) {
+	required := []*coredomaindefinition.Validation{{Rule: coredomaindefinition.ValidationRuleRequired}}
+	tests := []struct {
+		name     string
+		field    *coredomaindefinition.Field
+		expected []string
+	}{
+		// a zero money is not provided, its amount and currency are only checked when it is
+		{name: "money", field: &coredomaindefinition.Field{Type: coredomaindefinition.PrimitiveTypeMoney}, expected: []string{"omitempty"}},
+		{name: "optional money", field: &coredomaindefinition.Field{Type: coredomaindefinition.PrimitiveTypeMoney, Optional: true}, expected: []string{"omitempty"}},
+		{name: "required money", field: &coredomaindefinition.Field{Type: coredomaindefinition.PrimitiveTypeMoney, Validations: required}, expected: []string{"required"}},
+		{name: "decimal", field: &coredomaindefinition.Field{Type: coredomaindefinition.PrimitiveTypeDecimal}, expected: []string{"omitempty", "numeric"}},
+		{name: "required decimal", field: &coredomaindefinition.Field{Type: coredomaindefinition.PrimitiveTypeDecimal, Validations: required}, expected: []string{"required", "numeric"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tags, err := GetValidationTags(context.Background(), test.field)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if !reflect.DeepEqual(tags, test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, tags)
+			}
+		})
+	}
+}
@@ -0,0 +1,556 @@
+package domainbuilder
+
+import (
+	"context"
+
+	"github.com/cleogithub/golem/goGeneration/domain/consts"
+	"github.com/cleogithub/golem/goGeneration/domain/model"
+)
+
+const (
+	DEFAULT_VALIDATOR_NAME = "DefaultValidator"
+	MATCH_PATTERN_NAME     = "MatchPattern"
+	PATTERNS_NAME          = "patterns"
+)
+
+// MATCH_PATTERN_COMMENT documents the registration of the pattern rule, which go-playground/validator does not know
+const MATCH_PATTERN_COMMENT = MATCH_PATTERN_NAME + ` reports whether the whole value matches pattern, the rule of the pattern validate tags.
+Validators other than DefaultValidator must register the rule, e.g. with go-playground/validator:
+
+	validate.RegisterValidation("pattern", func(fl validator.FieldLevel) bool {
+		ok, err := usecase.MatchPattern(fl.Field().String(), fl.Param())
+		return err == nil && ok
+	})`
+
+// addDefaultValidator adds DefaultValidator, an implementation of the Validator port interpreting the validate tags with the standard library.
+// Its errors are ValidationErrors, one FieldError per invalid field, named by its json path.
+func (builder *DomainUsecaseBuilder) addDefaultValidator(ctx context.Context) {
+	if builder.Err != nil {
+		return
+	}
+
+	contextParam := &model.Param{Name: "ctx", Type: &model.PkgReference{Pkg: consts.CommonPkgs["context"], Reference: &model.ExternalType{Type: "Context"}}}
+	reflectValueType := &model.PkgReference{Pkg: consts.CommonPkgs["reflect"], Reference: &model.ExternalType{Type: "Value"}}
+	validationErrors := &model.Param{Name: "validationErrors", Type: &model.PointerType{Type: &model.ExternalType{Type: VALIDATION_ERRORS_NAME}}}
+
+	validator := &model.Struct{
+		Name:       DEFAULT_VALIDATOR_NAME,
+		MethodName: "validator",
+		Methods: []*model.Function{
+			{
+				Name:    VALIDATOR_VALIDATE_METHOD_NAME,
+				Args:    []*model.Param{contextParam, {Name: "request", Type: model.PrimitiveTypeInterface}},
+				Results: []*model.Param{{Type: model.PrimitiveTypeError}},
+				Content: func() (string, []*model.GoPkg) {
+					return `value := indirect(reflect.ValueOf(request))
+if value.Kind() != reflect.Struct {
+	return nil
+}
+validationErrors := ValidationErrors{}
+if err := validator.validateStruct(value, "", &validationErrors); err != nil {
+	return err
+}
+if len(validationErrors) > 0 {
+	return validationErrors
+}
+return nil
+`, []*model.GoPkg{consts.CommonPkgs["reflect"]}
+				},
+			},
+			{
+				Name:    VALIDATOR_IS_VALIDATION_ERROR_METHOD_NAME,
+				Args:    []*model.Param{contextParam, {Name: "err", Type: model.PrimitiveTypeError}},
+				Results: []*model.Param{{Type: model.PrimitiveTypeBool}},
+				Content: func() (string, []*model.GoPkg) {
+					return `var validationErrors ValidationErrors
+return errors.As(err, &validationErrors)
+`, []*model.GoPkg{consts.CommonPkgs["errors"]}
+				},
+			},
+			{
+				Name:    VALIDATOR_NEW_REFERENCE_ERROR_METHOD_NAME,
+				Args:    []*model.Param{contextParam, {Name: "reference", Type: model.PrimitiveTypeString}},
+				Results: []*model.Param{{Type: model.PrimitiveTypeError}},
+				Content: func() (string, []*model.GoPkg) {
+					return `return ValidationErrors{{Field: reference, Rule: "reference", Message: "does not reference an existing element", Params: []string{}}}` + consts.LN, nil
+				},
+			},
+			{
+				Name:    VALIDATOR_NEW_UNIQUE_ERROR_METHOD_NAME,
+				Args:    []*model.Param{contextParam, {Name: "field", Type: model.PrimitiveTypeString}},
+				Results: []*model.Param{{Type: model.PrimitiveTypeError}},
+				Content: func() (string, []*model.GoPkg) {
+					return `return ValidationErrors{{Field: field, Rule: "unique", Message: "is already used", Params: []string{}}}` + consts.LN, nil
+				},
+			},
+			{
+				Name: VALIDATOR_VALIDATE_MIME_TYPES_METHOD_NAME,
+				Args: []*model.Param{
+					contextParam,
+					{Name: "mimeTypes", Type: &model.ArrayType{Type: model.PrimitiveTypeString}},
+					{Name: "bytes", Type: model.PrimitiveTypeBytes},
+					{Name: "field", Type: model.PrimitiveTypeString},
+				},
+				Results: []*model.Param{{Type: model.PrimitiveTypeError}},
+				Content: func() (string, []*model.GoPkg) {
+					return `// an empty file is an optional file not provided
+if len(bytes) == 0 {
+	return nil
+}
+detected, _, _ := strings.Cut(http.DetectContentType(bytes), ";")
+for _, mimeType := range mimeTypes {
+	if mimeType == detected || mimeType == "*/*" {
+		return nil
+	}
+	if prefix, ok := strings.CutSuffix(mimeType, "/*"); ok && strings.HasPrefix(detected, prefix+"/") {
+		return nil
+	}
+	// text formats such as csv are sniffed as plain text
+	if detected == "text/plain" && strings.HasPrefix(mimeType, "text/") {
+		return nil
+	}
+}
+return ValidationErrors{{
+	Field:   field,
+	Rule:    "mimetypes",
+	Message: "must be of type " + strings.Join(mimeTypes, ", "),
+	Params:  mimeTypes,
+}}
+`, []*model.GoPkg{consts.CommonPkgs["strings"], consts.CommonPkgs["http"]}
+				},
+			},
+			{
+				Name: VALIDATOR_AS_VALIDATION_ERROR_METHOD_NAME,
+				Args: []*model.Param{
+					contextParam,
+					{Name: "field", Type: model.PrimitiveTypeString},
+					{Name: "message", Type: model.PrimitiveTypeString},
+				},
+				Results: []*model.Param{{Type: model.PrimitiveTypeError}},
+				Content: func() (string, []*model.GoPkg) {
+					return `return ValidationErrors{{Field: field, Rule: "invalid", Message: message, Params: []string{}}}` + consts.LN, nil
+				},
+			},
+			{
+				Name: "validateStruct",
+				Args: []*model.Param{
+					{Name: "value", Type: reflectValueType},
+					{Name: "path", Type: model.PrimitiveTypeString},
+					validationErrors,
+				},
+				Results: []*model.Param{{Type: model.PrimitiveTypeError}},
+				Content: func() (string, []*model.GoPkg) {
+					return `for i := 0; i < value.NumField(); i++ {
+	field := value.Type().Field(i)
+	if !field.IsExported() {
+		continue
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		continue
+	}
+	fieldPath := path
+	// fields of embedded structs are flattened in json
+	if name != "" || !field.Anonymous {
+		if name == "" {
+			name = field.Name
+		}
+		fieldPath = strings.TrimPrefix(path+"."+name, ".")
+	}
+
+	rules := []string{}
+	if tag := field.Tag.Get("validate"); tag != "" {
+		rules = strings.Split(tag, ",")
+	}
+	if err := validator.validateRules(value.Field(i), fieldPath, rules, validationErrors); err != nil {
+		return err
+	}
+}
+return nil
+`, []*model.GoPkg{consts.CommonPkgs["strings"]}
+				},
+			},
+			{
+				Name: "validateRules",
+				Args: []*model.Param{
+					{Name: "value", Type: reflectValueType},
+					{Name: "path", Type: model.PrimitiveTypeString},
+					{Name: "rules", Type: &model.ArrayType{Type: model.PrimitiveTypeString}},
+					validationErrors,
+				},
+				Results: []*model.Param{{Type: model.PrimitiveTypeError}},
+				Content: func() (string, []*model.GoPkg) {
+					return `// only the first failing rule of a field is reported
+for i, rule := range rules {
+	name, param, _ := strings.Cut(rule, "=")
+	// separators of the tag are escaped in parameters
+	param = strings.NewReplacer("0x2C", ",", "0x7C", "|").Replace(param)
+
+	switch name {
+	case "omitempty":
+		if !hasValue(value) {
+			return nil
+		}
+		continue
+	case "required":
+		if !hasValue(value) {
+			*validationErrors = append(*validationErrors, &FieldError{Field: path, Rule: name, Message: "is required", Params: []string{}})
+			return nil
+		}
+		continue
+	case "dive":
+		elements := indirect(value)
+		switch elements.Kind() {
+		case reflect.Slice, reflect.Array:
+			for j := 0; j < elements.Len(); j++ {
+				if err := validator.validateRules(elements.Index(j), fmt.Sprintf("%s[%d]", path, j), rules[i+1:], validationErrors); err != nil {
+					return err
+				}
+			}
+		case reflect.Map:
+			iter := elements.MapRange()
+			for iter.Next() {
+				if err := validator.validateRules(iter.Value(), fmt.Sprintf("%s[%v]", path, iter.Key()), rules[i+1:], validationErrors); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	// a nil pointer is only checked by required
+	v := indirect(value)
+	if !v.IsValid() {
+		return nil
+	}
+	message, err := validator.check(v, name, param)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if message != "" {
+		*validationErrors = append(*validationErrors, &FieldError{Field: path, Rule: name, Message: message, Params: ruleParams(name, param)})
+		return nil
+	}
+}
+
+v := indirect(value)
+switch v.Kind() {
+case reflect.Struct:
+	return validator.validateStruct(v, path, validationErrors)
+case reflect.Slice, reflect.Array:
+	if t := v.Type().Elem(); t.Kind() == reflect.Struct || (t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct) {
+		for j := 0; j < v.Len(); j++ {
+			if err := validator.validateRules(v.Index(j), fmt.Sprintf("%s[%d]", path, j), nil, validationErrors); err != nil {
+				return err
+			}
+		}
+	}
+}
+return nil
+`, []*model.GoPkg{consts.CommonPkgs["strings"], consts.CommonPkgs["reflect"], consts.CommonPkgs["fmt"]}
+				},
+			},
+			{
+				Name: "check",
+				Args: []*model.Param{
+					{Name: "v", Type: reflectValueType},
+					{Name: "rule", Type: model.PrimitiveTypeString},
+					{Name: "param", Type: model.PrimitiveTypeString},
+				},
+				Results: []*model.Param{{Type: model.PrimitiveTypeString}, {Type: model.PrimitiveTypeError}},
+				Content: func() (string, []*model.GoPkg) {
+					return `// the message of rule when v fails it, empty when v passes
+switch rule {
+case "min", "max", "len", "gt", "gte", "lt", "lte":
+	return validator.checkBound(v, rule, param)
+case "numeric":
+	if v.Kind() != reflect.String {
+		return "", nil
+	}
+case "oneof":
+	// the underlying value, a String method could format it differently
+	actual := fmt.Sprint(v.Interface())
+	switch {
+	case v.Kind() == reflect.String:
+		actual = v.String()
+	case v.CanInt():
+		actual = fmt.Sprint(v.Int())
+	case v.CanUint():
+		actual = fmt.Sprint(v.Uint())
+	}
+	values := splitOneOf(param)
+	for _, value := range values {
+		if value == actual {
+			return "", nil
+		}
+	}
+	return "must be one of " + strings.Join(values, ", "), nil
+}
+
+switch rule {
+case "email", "url", "ip", "datetime", "startswith", "endswith", "uuid", "ulid", "hexcolor", "alphanum", "numeric", "iso4217", "pattern":
+default:
+	return "", fmt.Errorf("unknown validation rule %s", rule)
+}
+if v.Kind() != reflect.String {
+	return "", fmt.Errorf("validation %s applies to strings, not to %s", rule, v.Type())
+}
+s := v.String()
+switch rule {
+case "email":
+	if address, err := mail.ParseAddress(s); err != nil || address.Address != s {
+		return "must be a valid email address", nil
+	}
+case "url":
+	if u, err := url.Parse(s); err != nil || u.Scheme == "" || (u.Host == "" && u.Opaque == "") {
+		return "must be a valid URL", nil
+	}
+case "ip":
+	if net.ParseIP(s) == nil {
+		return "must be a valid IP address", nil
+	}
+case "datetime":
+	if _, err := time.Parse(param, s); err != nil {
+		return "must match the layout " + param, nil
+	}
+case "startswith":
+	if !strings.HasPrefix(s, param) {
+		return "must start with " + param, nil
+	}
+case "endswith":
+	if !strings.HasSuffix(s, param) {
+		return "must end with " + param, nil
+	}
+case "uuid":
+	return validator.checkPattern(s, "[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}", "must be a valid UUID")
+case "ulid":
+	return validator.checkPattern(s, "[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}", "must be a valid ULID")
+case "hexcolor":
+	return validator.checkPattern(s, "#(?:[0-9a-fA-F]{3,4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})", "must be a hexadecimal color")
+case "alphanum":
+	return validator.checkPattern(s, "[a-zA-Z0-9]+", "must contain only letters and digits")
+case "numeric":
+	return validator.checkPattern(s, "[-+]?[0-9]+(?:\\.[0-9]+)?", "must be a number")
+case "iso4217":
+	return validator.checkPattern(s, "[A-Z]{3}", "must be a currency code")
+case "pattern":
+	return validator.checkPattern(s, param, "must match "+param)
+}
+return "", nil
+`, []*model.GoPkg{
+						consts.CommonPkgs["reflect"], consts.CommonPkgs["fmt"], consts.CommonPkgs["strings"], consts.CommonPkgs["mail"],
+						consts.CommonPkgs["url"], consts.CommonPkgs["net"], consts.CommonPkgs["time"],
+					}
+				},
+			},
+			{
+				Name: "checkPattern",
+				Args: []*model.Param{
+					{Name: "s", Type: model.PrimitiveTypeString},
+					{Name: "pattern", Type: model.PrimitiveTypeString},
+					{Name: "message", Type: model.PrimitiveTypeString},
+				},
+				Results: []*model.Param{{Type: model.PrimitiveTypeString}, {Type: model.PrimitiveTypeError}},
+				Content: func() (string, []*model.GoPkg) {
+					return `ok, err := ` + MATCH_PATTERN_NAME + `(s, pattern)
+if err != nil {
+	return "", err
+}
+if !ok {
+	return message, nil
+}
+return "", nil
+`, nil
+				},
+			},
+			{
+				Name: "checkBound",
+				Args: []*model.Param{
+					{Name: "v", Type: reflectValueType},
+					{Name: "rule", Type: model.PrimitiveTypeString},
+					{Name: "param", Type: model.PrimitiveTypeString},
+				},
+				Results: []*model.Param{{Type: model.PrimitiveTypeString}, {Type: model.PrimitiveTypeError}},
+				Content: func() (string, []*model.GoPkg) {
+					return `// numbers and decimals are compared by value, strings, arrays and maps by length.
+// min, max and len compare the length of decimals, as they are strings.
+bound, ok := new(big.Rat).SetString(param)
+if !ok {
+	return "", fmt.Errorf("validation %s expects a number, got %s", rule, param)
+}
+
+actual, isLength := new(big.Rat), false
+decimal, isDecimal := v.Interface().(interface{ Rat() *big.Rat })
+switch {
+case isDecimal && rule != "min" && rule != "max" && rule != "len":
+	actual = decimal.Rat()
+case v.CanInt():
+	actual.SetInt64(v.Int())
+case v.CanUint():
+	actual.SetUint64(v.Uint())
+case v.CanFloat():
+	if actual.SetFloat64(v.Float()) == nil {
+		return "must be a finite number", nil
+	}
+case v.Kind() == reflect.String:
+	actual.SetInt64(int64(utf8.RuneCountInString(v.String())))
+	isLength = true
+case v.Kind() == reflect.Slice || v.Kind() == reflect.Array || v.Kind() == reflect.Map:
+	actual.SetInt64(int64(v.Len()))
+	isLength = true
+default:
+	return "", fmt.Errorf("validation %s does not apply to %s", rule, v.Type())
+}
+
+comparison := actual.Cmp(bound)
+failed := map[string]bool{
+	"min": comparison < 0,
+	"gte": comparison < 0,
+	"max": comparison > 0,
+	"lte": comparison > 0,
+	"len": comparison != 0,
+	"gt":  comparison <= 0,
+	"lt":  comparison >= 0,
+}[rule]
+if !failed {
+	return "", nil
+}
+
+if isLength {
+	unit := " elements"
+	if v.Kind() == reflect.String {
+		unit = " characters"
+	}
+	return map[string]string{
+		"min": "must have at least ",
+		"gte": "must have at least ",
+		"max": "must have at most ",
+		"lte": "must have at most ",
+		"len": "must have exactly ",
+		"gt":  "must have more than ",
+		"lt":  "must have less than ",
+	}[rule] + param + unit, nil
+}
+return map[string]string{
+	"min": "must be at least ",
+	"gte": "must be at least ",
+	"max": "must be at most ",
+	"lte": "must be at most ",
+	"len": "must be ",
+	"gt":  "must be greater than ",
+	"lt":  "must be less than ",
+}[rule] + param, nil
+`, []*model.GoPkg{bigRat.Pkg, consts.CommonPkgs["fmt"], consts.CommonPkgs["reflect"], consts.CommonPkgs["utf8"]}
+				},
+			},
+		},
+	}
+
+	builder.domainBuilder.Domain.Files = append(builder.domainBuilder.Domain.Files, &model.File{
+		Name: DEFAULT_VALIDATOR_NAME,
+		Pkg:  builder.domainBuilder.GetUsecasePackage(),
+		Elements: []interface{}{
+			validator,
+			&model.Function{
+				Name:    "New" + DEFAULT_VALIDATOR_NAME,
+				Results: []*model.Param{{Type: &model.PointerType{Type: validator}}},
+				Content: func() (string, []*model.GoPkg) {
+					return "return &" + DEFAULT_VALIDATOR_NAME + "{}", nil
+				},
+			},
+			// compiled patterns of MatchPattern
+			&model.Var{
+				Name:  PATTERNS_NAME,
+				Value: &model.PkgReference{Pkg: consts.CommonPkgs["sync"], Reference: &model.ExternalType{Type: "Map{}"}},
+			},
+			&model.Function{
+				Comment: MATCH_PATTERN_COMMENT,
+				Name:    MATCH_PATTERN_NAME,
+				Args: []*model.Param{
+					{Name: "value", Type: model.PrimitiveTypeString},
+					{Name: "pattern", Type: model.PrimitiveTypeString},
+				},
+				Results: []*model.Param{{Type: model.PrimitiveTypeBool}, {Type: model.PrimitiveTypeError}},
+				Content: func() (string, []*model.GoPkg) {
+					return `compiled, ok := ` + PATTERNS_NAME + `.Load(pattern)
+if !ok {
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return false, err
+	}
+	compiled, _ = ` + PATTERNS_NAME + `.LoadOrStore(pattern, re)
+}
+return compiled.(*regexp.Regexp).MatchString(value), nil`, []*model.GoPkg{consts.CommonPkgs["regexp"]}
+				},
+			},
+			&model.Function{
+				Name:    "hasValue",
+				Args:    []*model.Param{{Name: "value", Type: reflectValueType}},
+				Results: []*model.Param{{Type: model.PrimitiveTypeBool}},
+				Content: func() (string, []*model.GoPkg) {
+					return `// neither nil nor the zero value
+switch value.Kind() {
+case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+	return !value.IsNil()
+}
+return value.IsValid() && !value.IsZero()`, []*model.GoPkg{consts.CommonPkgs["reflect"]}
+				},
+			},
+			&model.Function{
+				Name:    "indirect",
+				Args:    []*model.Param{{Name: "value", Type: reflectValueType}},
+				Results: []*model.Param{{Type: reflectValueType}},
+				Content: func() (string, []*model.GoPkg) {
+					return `// the value pointed by value, invalid for nil pointers
+for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+	if value.IsNil() {
+		return reflect.Value{}
+	}
+	value = value.Elem()
+}
+return value`, []*model.GoPkg{consts.CommonPkgs["reflect"]}
+				},
+			},
+			&model.Function{
+				Name: "ruleParams",
+				Args: []*model.Param{
+					{Name: "rule", Type: model.PrimitiveTypeString},
+					{Name: "param", Type: model.PrimitiveTypeString},
+				},
+				Results: []*model.Param{{Type: &model.ArrayType{Type: model.PrimitiveTypeString}}},
+				Content: func() (string, []*model.GoPkg) {
+					return `// the parameters of rule as reported in its FieldError
+if rule == "oneof" {
+	return splitOneOf(param)
+}
+if param == "" {
+	return []string{}
+}
+return []string{param}`, nil
+				},
+			},
+			&model.Function{
+				Name:    "splitOneOf",
+				Args:    []*model.Param{{Name: "param", Type: model.PrimitiveTypeString}},
+				Results: []*model.Param{{Type: &model.ArrayType{Type: model.PrimitiveTypeString}}},
+				Content: func() (string, []*model.GoPkg) {
+					return `// values are separated by spaces, single quotes keep the spaces of a value
+values := []string{}
+for {
+	param = strings.TrimLeft(param, " ")
+	if param == "" {
+		return values
+	}
+	value := ""
+	if strings.HasPrefix(param, "'") {
+		value, param, _ = strings.Cut(param[1:], "'")
+	} else {
+		value, param, _ = strings.Cut(param, " ")
+	}
+	values = append(values, value)
+}`, []*model.GoPkg{consts.CommonPkgs["strings"]}
+				},
+			},
+		},
+	})
+}
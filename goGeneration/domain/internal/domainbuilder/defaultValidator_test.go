@@ -0,0 +1,42 @@
+package domainbuilder
+
+import (
+	"testing"
+
+	"github.com/cleogithub/golem/coredomaindefinition"
+)
+
+func TestDefaultValidatorGeneration(t *testing.T) {
+	shop := coredomaindefinition.NewModel("shop")
+	shop.Fields = []*coredomaindefinition.Field{
+		{Name: "name", Type: coredomaindefinition.PrimitiveTypeString},
+	}
+
+	files := generate(t, withCRUD(newTestDomain(shop), shop))
+
+	assertContains(t, files.goFile(t, "domain/usecase/validator"),
+		"// Validator validates the requests of the usecases, DefaultValidator is an implementation.",
+	)
+	validator := files.goFile(t, "domain/usecase/defaultValidator")
+	assertContains(t, validator,
+		"func NewDefaultValidator() *DefaultValidator {",
+		"func (validator *DefaultValidator) Validate(ctx context.Context, request interface{}) error {",
+		// the escaped separators of the tags are restored
+		`param = strings.NewReplacer("0x2C", ",", "0x7C", "|").Replace(param)`,
+		// patterns match the whole value
+		`regexp.Compile("^(?:" + pattern + ")$")`,
+	)
+
+	// a field without value stops at omitempty and fails at required, nil pointers are only checked by required
+	rules := goFunction(t, validator, "func (validator *DefaultValidator) validateRules(")
+	assertContains(t, rules,
+		"case \"omitempty\":\n\t\t\tif !hasValue(value) {\n\t\t\t\treturn nil\n\t\t\t}",
+		"case \"required\":\n\t\t\tif !hasValue(value) {\n\t\t\t\t*validationErrors = append(*validationErrors, &FieldError{Field: path, Rule: name, Message: \"is required\", Params: []string{}})",
+		"v := indirect(value)\n\t\tif !v.IsValid() {\n\t\t\treturn nil\n\t\t}",
+		"case reflect.Struct:\n\t\treturn validator.validateStruct(v, path, validationErrors)",
+	)
+	// unknown rules are errors of the generation, not validation errors of the request
+	assertContains(t, goFunction(t, validator, "func (validator *DefaultValidator) check("),
+		`return "", fmt.Errorf("unknown validation rule %s", rule)`,
+	)
+}
@@ -0,0 +1,168 @@
+package domainbuilder
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/cleogithub/golem/coredomaindefinition"
+	"github.com/cleogithub/golem/goGeneration/domain/consts"
+	"github.com/cleogithub/golem/goGeneration/domain/model"
+)
+
+// NOW_VAR_NAME is the variable holding the creation time, shared by every default generated with now
+const NOW_VAR_NAME = "now"
+
+// formatDefaultNumber returns a numeric default as written in go, SQL and javascript
+func formatDefaultNumber(value interface{}) string {
+	switch v := value.(type) {
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+			return strconv.FormatInt(int64(v), 10)
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// GetDefaultValue returns the go expression of the default of f, typed as the field without its pointer.
+// usesNow tells the expression reads NOW_VAR_NAME, which must be declared as a time.Time.
+func (domainBuilder *domainBuilder) GetDefaultValue(ctx context.Context, f *coredomaindefinition.Field) (value string, usesNow bool, pkgs []*model.GoPkg) {
+	switch v := f.Default.(type) {
+	case coredomaindefinition.DefaultGenerator:
+		switch v {
+		case coredomaindefinition.DefaultGeneratorNow:
+			if civil := getCivilType(f.Type); civil != nil {
+				return fmt.Sprintf("%s.%sOf(%s)", domainBuilder.GetConstsPackage().Alias, civil.name, NOW_VAR_NAME), true, []*model.GoPkg{domainBuilder.GetConstsPackage()}
+			}
+			return NOW_VAR_NAME, true, nil
+		case coredomaindefinition.DefaultGeneratorUUID:
+			return fmt.Sprintf("%s.NewString()", consts.CommonPkgs["uuid"].Alias), false, []*model.GoPkg{consts.CommonPkgs["uuid"]}
+		}
+	case string:
+		switch t := f.Type.(type) {
+		case *coredomaindefinition.Enum:
+			return fmt.Sprintf("%s.%s", domainBuilder.GetModelPackage().Alias, GetEnumValueName(ctx, t, v)), false, []*model.GoPkg{domainBuilder.GetModelPackage()}
+		}
+		if civil := getCivilType(f.Type); civil != nil {
+			return fmt.Sprintf("%s.%s(%s)", domainBuilder.GetConstsPackage().Alias, civil.name, strconv.Quote(v)), false, []*model.GoPkg{domainBuilder.GetConstsPackage()}
+		}
+		if f.Type == coredomaindefinition.PrimitiveTypeDecimal {
+			return fmt.Sprintf("%s.%s(%s)", domainBuilder.GetModelPackage().Alias, DECIMAL_NAME, strconv.Quote(v)), false, []*model.GoPkg{domainBuilder.GetModelPackage()}
+		}
+		return strconv.Quote(v), false, nil
+	case bool:
+		return strconv.FormatBool(v), false, nil
+	case int, float64:
+		switch f.Type {
+		case coredomaindefinition.PrimitiveTypeInt:
+			return fmt.Sprintf("%s(%s)", model.PrimitiveTypeInt, formatDefaultNumber(v)), false, nil
+		case coredomaindefinition.PrimitiveTypeDecimal:
+			return fmt.Sprintf(`%s.%s("%s")`, domainBuilder.GetModelPackage().Alias, DECIMAL_NAME, formatDefaultNumber(v)), false, []*model.GoPkg{domainBuilder.GetModelPackage()}
+		}
+		return fmt.Sprintf("%s(%s)", model.PrimitiveTypeFloat, formatDefaultNumber(v)), false, nil
+	}
+	return "", false, nil
+}
+
+// GetDefaultAssignment returns the code setting target to the default of f when it is empty: nil for optional fields, the zero value otherwise.
+// It is empty when f has no default or when the default is the zero value.
+func (domainBuilder *domainBuilder) GetDefaultAssignment(ctx context.Context, f *coredomaindefinition.Field, target string) (code string, usesNow bool, pkgs []*model.GoPkg) {
+	value, usesNow, pkgs := domainBuilder.GetDefaultValue(ctx, f)
+	if value == "" {
+		return "", false, nil
+	}
+
+	if f.Optional {
+		code = fmt.Sprintf("if %s == nil {", target) + consts.LN
+		code += fmt.Sprintf("value := %s", value) + consts.LN
+		code += fmt.Sprintf("%s = &value", target) + consts.LN
+		code += "}" + consts.LN
+		return code, usesNow, pkgs
+	}
+
+	switch f.Type {
+	case coredomaindefinition.PrimitiveTypeBool:
+		// only false is allowed on non optional bools, it is already the zero value
+		return "", false, nil
+	case coredomaindefinition.PrimitiveTypeInt, coredomaindefinition.PrimitiveTypeFloat:
+		code = fmt.Sprintf("if %s == 0 {", target) + consts.LN
+	case coredomaindefinition.PrimitiveTypeDateTime:
+		code = fmt.Sprintf("if %s.IsZero() {", target) + consts.LN
+	default:
+		code = fmt.Sprintf(`if %s == "" {`, target) + consts.LN
+	}
+	code += fmt.Sprintf("%s = %s", target, value) + consts.LN
+	code += "}" + consts.LN
+	return code, usesNow, pkgs
+}
+
+// GetDefaultGormTags returns the gorm column default of f, nil when the database can not generate it
+func (domainBuilder *domainBuilder) GetDefaultGormTags(ctx context.Context, f *coredomaindefinition.Field) []string {
+	value := ""
+	switch v := f.Default.(type) {
+	case coredomaindefinition.DefaultGenerator:
+		switch v {
+		case coredomaindefinition.DefaultGeneratorNow:
+			switch f.Type {
+			case coredomaindefinition.PrimitiveTypeDate:
+				value = "CURRENT_DATE"
+			case coredomaindefinition.PrimitiveTypeTime:
+				value = "CURRENT_TIME"
+			default:
+				value = "CURRENT_TIMESTAMP"
+			}
+		case coredomaindefinition.DefaultGeneratorUUID:
+			// the create usecase generates uuids, the SQL generating them depends on the database
+		}
+	case string:
+		if f.Type == coredomaindefinition.PrimitiveTypeDecimal {
+			value = v
+		} else if !strings.ContainsAny(v, "'\"()\\`") {
+			// gorm trims the quotes and binds the value, parentheses would make it raw SQL
+			value = "'" + strings.ReplaceAll(v, ";", `\;`) + "'"
+		}
+	case bool:
+		value = strconv.FormatBool(v)
+	case int, float64:
+		value = formatDefaultNumber(v)
+	}
+	if value == "" {
+		return nil
+	}
+	return []string{"default:" + value}
+}
+
+// GetJSDefault returns the javascript expression of the default of f, empty when f has none
+func GetJSDefault(ctx context.Context, f *coredomaindefinition.Field) string {
+	switch v := f.Default.(type) {
+	case coredomaindefinition.DefaultGenerator:
+		switch v {
+		case coredomaindefinition.DefaultGeneratorNow:
+			switch f.Type {
+			case coredomaindefinition.PrimitiveTypeDate:
+				return "new Date().toISOString().slice(0, 10)"
+			case coredomaindefinition.PrimitiveTypeTime:
+				return "new Date().toTimeString().slice(0, 8)"
+			}
+			return "new Date()"
+		case coredomaindefinition.DefaultGeneratorUUID:
+			return "crypto.randomUUID()"
+		}
+	case string:
+		return JSString(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int, float64:
+		if f.Type == coredomaindefinition.PrimitiveTypeDecimal {
+			// decimals are strings to keep their precision
+			return JSString(formatDefaultNumber(v))
+		}
+		return formatDefaultNumber(v)
+	}
+	return ""
+}
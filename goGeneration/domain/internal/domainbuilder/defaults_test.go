@@ -0,0 +1,61 @@
+package domainbuilder
+
+import (
+	"testing"
+
+	"github.com/cleogithub/golem/coredomaindefinition"
+)
+
+func TestDefaultGeneration(t *testing.T) {
+	status := &coredomaindefinition.Enum{Name: "status", Values: []string{"pending", "done"}}
+	shop := coredomaindefinition.NewModel("shop")
+	shop.Fields = []*coredomaindefinition.Field{
+		{Name: "open", Type: coredomaindefinition.PrimitiveTypeBool, Optional: true, Default: true},
+		{Name: "label", Type: coredomaindefinition.PrimitiveTypeString, Default: "it's"},
+		{Name: "ref", Type: coredomaindefinition.PrimitiveTypeString, Default: coredomaindefinition.DefaultGeneratorUUID},
+		{Name: "status", Type: status, Default: "pending"},
+		{Name: "count", Type: coredomaindefinition.PrimitiveTypeInt, Default: 3},
+	}
+	definition := withCRUD(newTestDomain(shop), shop)
+	definition.Enums = []*coredomaindefinition.Enum{status}
+
+	files := generate(t, definition)
+
+	// the create usecase applies the defaults of the fields left empty and sets the timestamps
+	create := goFunction(t, files.goFile(t, "domain/usecase/shopUsecaseCRUD"), "func (crud *ShopUsecaseCRUD) CreateShop(")
+	assertContains(t, create,
+		"now := time.Now()",
+		"CreatedAt: now,\n\t\tUpdatedAt: now,",
+		"if entity.Open == nil {\n\t\tvalue := true\n\t\tentity.Open = &value\n\t}",
+		"if entity.Label == \"\" {\n\t\tentity.Label = \"it's\"\n\t}",
+		"if entity.Ref == \"\" {\n\t\tentity.Ref = uuid.NewString()\n\t}",
+		"if entity.Status == \"\" {\n\t\tentity.Status = model.StatusPending\n\t}",
+		"if entity.Count == 0 {\n\t\tentity.Count = int64(3)\n\t}",
+	)
+	update := goFunction(t, files.goFile(t, "domain/usecase/shopUsecaseCRUD"), "func (crud *ShopUsecaseCRUD) UpdateShop(")
+	assertContains(t, update, "UpdatedAt: time.Now(),")
+	assertNotContains(t, update, "entity.Label = \"it's\"")
+
+	// uuids are generated by the create usecase, not by a column default depending on the database
+	gormModel := files.goFile(t, "adapter/repository/gormadapter/shop")
+	assertContains(t, gormModel,
+		"`gorm:\"column:open;default:true\"`",
+		"`gorm:\"column:status;check:status IN ('pending','done');default:'pending'\"`",
+		"`gorm:\"column:count;default:3\"`",
+		"Ref       string          `gorm:\"column:ref\"`",
+	)
+
+	// the create request class has the defaults, the entity keeps false, 0 and empty strings of the server
+	assertContains(t, files.jsFile(t, "createShop"),
+		"constructor(count = 3,label = 'it\\'s',open = true,ref = crypto.randomUUID(),status = 'pending') {",
+		"if ( data.open != null ) { this.open = data.open }",
+	)
+	entities := files.jsFile(t, "entities")
+	assertContains(t, entities,
+		"constructor(id,createdAt,updatedAt,deletedAt,open,label,ref,status,count) {",
+		"if (data.open != null) this.open = data.open;",
+		"if (data.label != null) this.label = data.label;",
+		"if (data.count != null) this.count = data.count;",
+	)
+	assertNotContains(t, entities, "randomUUID", "= true", "'pending'")
+}
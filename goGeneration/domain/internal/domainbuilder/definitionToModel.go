@@ -25,9 +25,32 @@ func (b *domainBuilder) TypeDefinitionToType(ctx context.Context, typeDefinition
 			return model.PrimitiveTypeByte, nil
 		case coredomaindefinition.PrimitiveTypeBytes.GetType(), coredomaindefinition.PrimitiveTypeFile.GetType():
 			return model.PrimitiveTypeBytes, nil
-		case coredomaindefinition.PrimitiveTypeDate.GetType(),
-			coredomaindefinition.PrimitiveTypeDateTime.GetType(),
-			coredomaindefinition.PrimitiveTypeTime.GetType():
+		case coredomaindefinition.PrimitiveTypeDecimal.GetType():
+			return &model.PkgReference{
+				Pkg:       b.Domain.Architecture.ModelPkg,
+				Reference: &model.ExternalType{Type: DECIMAL_NAME},
+			}, nil
+		case coredomaindefinition.PrimitiveTypeMoney.GetType():
+			return &model.PkgReference{
+				Pkg:       b.Domain.Architecture.ModelPkg,
+				Reference: &model.ExternalType{Type: MONEY_NAME},
+			}, nil
+		case coredomaindefinition.PrimitiveTypeJSON.GetType():
+			return &model.PkgReference{
+				Pkg:       consts.CommonPkgs["json"],
+				Reference: &model.ExternalType{Type: "RawMessage"},
+			}, nil
+		case coredomaindefinition.PrimitiveTypeDate.GetType():
+			return &model.PkgReference{
+				Pkg:       b.GetConstsPackage(),
+				Reference: &model.ExternalType{Type: DATE_NAME},
+			}, nil
+		case coredomaindefinition.PrimitiveTypeTime.GetType():
+			return &model.PkgReference{
+				Pkg:       b.GetConstsPackage(),
+				Reference: &model.ExternalType{Type: TIME_OF_DAY_NAME},
+			}, nil
+		case coredomaindefinition.PrimitiveTypeDateTime.GetType():
 			return &model.PkgReference{
 				Pkg: consts.CommonPkgs["time"],
 				Reference: &model.ExternalType{
@@ -46,6 +69,31 @@ func (b *domainBuilder) TypeDefinitionToType(ctx context.Context, typeDefinition
 		return &model.ArrayType{
 			Type: t,
 		}, nil
+	case *coredomaindefinition.Map:
+		key, err := b.TypeDefinitionToType(ctx, ty.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := b.TypeDefinitionToType(ctx, ty.Value)
+		if err != nil {
+			return nil, err
+		}
+		return &model.MapType{
+			Key:   key,
+			Value: value,
+		}, nil
+	case *coredomaindefinition.Enum:
+		return &model.PkgReference{
+			Pkg:       b.Domain.Architecture.ModelPkg,
+			Reference: &model.ExternalType{Type: GetEnumName(ctx, ty)},
+		}, nil
+	case *coredomaindefinition.ValueObject:
+		return &model.PkgReference{
+			Pkg:       b.Domain.Architecture.ModelPkg,
+			Reference: &model.ExternalType{Type: GetValueObjectName(ctx, ty)},
+		}, nil
+	case *coredomaindefinition.ExternalType:
+		return GetExternalTypeReference(ctx, ty), nil
 	case *coredomaindefinition.Model:
 		return &model.PointerType{
 			Type: &model.PkgReference{
@@ -64,15 +112,27 @@ func (b *domainBuilder) FieldDefinitionToField(ctx context.Context, fieldDefinit
 		return nil, err
 	}
 
+	jsonTag := &model.Tag{
+		Name:   "json",
+		Values: []string{fieldDefinition.Name},
+	}
+
+	// optional values are pointers so nil tells "not provided" from zero, slices, maps and pointers are already nullable
+	if fieldDefinition.Optional {
+		switch t.(type) {
+		case *model.ArrayType, *model.PointerType, *model.MapType:
+		default:
+			if t != model.PrimitiveTypeBytes && fieldDefinition.Type != coredomaindefinition.PrimitiveTypeJSON {
+				t = &model.PointerType{Type: t}
+			}
+		}
+		jsonTag.Values = append(jsonTag.Values, "omitempty")
+	}
+
 	return &model.Field{
-		Name: GetFieldName(ctx, fieldDefinition.Name),
-		Type: t,
-		Tags: []*model.Tag{
-			{
-				Name:   "json",
-				Values: []string{fieldDefinition.Name},
-			},
-		},
+		Name:     GetFieldName(ctx, fieldDefinition.Name),
+		Type:     t,
+		Tags:     []*model.Tag{jsonTag},
 		JsonName: fieldDefinition.Name,
 	}, nil
 }
@@ -0,0 +1,37 @@
+package domainbuilder
+
+import (
+	"testing"
+
+	"github.com/cleogithub/golem/coredomaindefinition"
+)
+
+func TestOptionalFieldGeneration(t *testing.T) {
+	shop := coredomaindefinition.NewModel("shop")
+	shop.Fields = []*coredomaindefinition.Field{
+		{Name: "name", Type: coredomaindefinition.PrimitiveTypeString},
+		{Name: "nickname", Type: coredomaindefinition.PrimitiveTypeString, Optional: true},
+		{Name: "age", Type: coredomaindefinition.PrimitiveTypeInt, Optional: true},
+		{Name: "tags", Type: &coredomaindefinition.Array{Type: coredomaindefinition.PrimitiveTypeString}, Optional: true},
+	}
+
+	files := generate(t, withCRUD(newTestDomain(shop), shop))
+
+	// slices are already nullable, other optional values are pointers
+	model := files.goFile(t, "domain/model/shop")
+	assertContains(t, model,
+		"Name      string    `json:\"name\"`",
+		"Nickname  *string   `json:\"nickname,omitempty\"`",
+		"Age       *int64    `json:\"age,omitempty\"`",
+		"Tags      []string  `json:\"tags,omitempty\"`",
+	)
+	gormModel := files.goFile(t, "adapter/repository/gormadapter/shop")
+	assertContains(t, gormModel,
+		"Nickname  *string         `gorm:\"column:nickname\"`",
+		"Age       *int64          `gorm:\"column:age\"`",
+		"Nickname:  gormModel.Nickname,",
+	)
+	assertNotContains(t, gormModel, "*gormModel.Nickname", "&gormModel.Nickname")
+	// an update request tells a field not provided from its zero value
+	assertContains(t, files.goFile(t, "domain/usecase/structs"), "Nickname *string  `json:\"nickname,omitempty\"`")
+}
@@ -3,6 +3,8 @@ package domainbuilder
 import (
 	"context"
 	"fmt"
+	"strings"
+	"unicode"
 
 	"github.com/cleogithub/golem-common/pkg/stringtool"
 	"github.com/cleogithub/golem/coredomaindefinition"
@@ -73,79 +75,56 @@ func NewDomainBuilder(
 }
 
 func (builder *domainBuilder) setArchitecture(ctx context.Context) *domainBuilder {
+	configuration := builder.Definition.Configuration
+	domainPath := configuration.GetDomainPath() + "/" + configuration.GetDomainFolder()
+	controllerPath := configuration.GetAdapterPath() + "/" + configuration.GetAdapterFolder() + "/" + configuration.GetControllerFolder()
+	adapterRepositoryPath := configuration.GetAdapterPath() + "/" + configuration.GetAdapterFolder() + "/" + configuration.GetRepositoryFolder()
+	sdkPath := configuration.GetSdkPath() + "/" + configuration.GetSdkFolder()
+
 	builder.Domain.Architecture = &model.Architecture{
-		ModelPkg: &model.GoPkg{
-			ShortName: "model",
-			Alias:     "model",
-			FullName: fmt.Sprintf(
-				"%s/domain/model",
-				builder.Definition.Configuration.Package,
-			),
-		},
-		RepositoryPkg: &model.GoPkg{
-			ShortName: "repository",
-			Alias:     "repository",
-			FullName: fmt.Sprintf(
-				"%s/domain/port/repository",
-				builder.Definition.Configuration.Package,
-			),
-		},
-		UsecasePkg: &model.GoPkg{
-			ShortName: "usecase",
-			Alias:     "usecase",
-			FullName: fmt.Sprintf(
-				"%s/domain/usecase",
-				builder.Definition.Configuration.Package,
-			),
-		},
-		ControllerPkg: &model.GoPkg{
-			ShortName: "controller",
-			Alias:     "controller",
-			FullName: fmt.Sprintf(
-				"%s/adapter/controller",
-				builder.Definition.Configuration.Package,
-			),
-		},
-		HttpControllerPkg: &model.GoPkg{
-			ShortName: "httpadapter",
-			Alias:     "httpadapter",
-			FullName: fmt.Sprintf(
-				"%s/adapter/controller/httpadapter",
-				builder.Definition.Configuration.Package,
-			),
-		},
-		GormAdapterPkg: &model.GoPkg{
-			ShortName: "gormadapter",
-			Alias:     "gormadapter",
-			FullName: fmt.Sprintf(
-				"%s/adapter/repository/gormadapter",
-				builder.Definition.Configuration.Package,
-			),
-		},
-		SdkPkg: &model.GoPkg{
-			ShortName: "client",
-			Alias:     "client",
-			FullName: fmt.Sprintf(
-				"%s/sdk/client",
-				builder.Definition.Configuration.Package,
-			),
-		},
-		ConstsPkg: &model.GoPkg{
-			ShortName: "consts",
-			Alias:     "consts",
-			FullName: fmt.Sprintf(
-				"%s/domain/consts",
-				builder.Definition.Configuration.Package,
+		ModelPkg:          builder.newArchitecturePkg(ctx, configuration.GetModelPath(), configuration.GetModelFolder()),
+		RepositoryPkg:     builder.newArchitecturePkg(ctx, configuration.GetRepositoryPath(), configuration.GetRepositoryFolder()),
+		UsecasePkg:        builder.newArchitecturePkg(ctx, configuration.GetUsecasePath(), configuration.GetUsecaseFolder()),
+		ControllerPkg:     builder.newArchitecturePkg(ctx, controllerPath),
+		HttpControllerPkg: builder.newArchitecturePkg(ctx, controllerPath, configuration.GetHttpFolder()),
+		GormAdapterPkg:    builder.newArchitecturePkg(ctx, adapterRepositoryPath, configuration.GetGormFolder()),
+		SdkPkg:            builder.newArchitecturePkg(ctx, sdkPath, configuration.GetClientFolder()),
+		ConstsPkg:         builder.newArchitecturePkg(ctx, domainPath, configuration.GetConstsFolder()),
+		JavascriptClient: stringtool.RemoveDuplicate(
+			fmt.Sprintf(
+				"%s/%s/%s",
+				builder.Definition.Name,
+				sdkPath,
+				configuration.GetJavascriptClientFolder(),
 			),
-		},
-		JavascriptClient: fmt.Sprintf(
-			"%s/sdk/JS",
-			builder.Definition.Name,
+			'/',
 		),
 	}
 	return builder
 }
 
+// newArchitecturePkg returns the package located at the joined path in the domain package.
+// The package name is the last folder, reduced to lower case letters and digits.
+func (builder *domainBuilder) newArchitecturePkg(ctx context.Context, path ...string) *model.GoPkg {
+	fullName := stringtool.RemoveDuplicate(
+		strings.TrimSuffix(builder.Definition.Configuration.Package+"/"+strings.Join(path, "/"), "/"),
+		'/',
+	)
+
+	name := ""
+	for _, r := range strings.ToLower(fullName[strings.LastIndex(fullName, "/")+1:]) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			name += string(r)
+		}
+	}
+
+	return &model.GoPkg{
+		ShortName: name,
+		Alias:     name,
+		FullName:  fullName,
+	}
+}
+
 func (domainBuilder *domainBuilder) AddBuilder(ctx context.Context, builder Builder) {
 	if domainBuilder.err != nil {
 		return
@@ -174,7 +153,7 @@ func (domainBuilder *domainBuilder) AddBuilder(ctx context.Context, builder Buil
 }
 
 func (domainBuilder *domainBuilder) NewModelBuilder(ctx context.Context, modelDefinition *coredomaindefinition.Model) Builder {
-	return NewModelBuilder(ctx, domainBuilder, modelDefinition, domainBuilder.DefaultModelFields)
+	return NewModelBuilder(ctx, domainBuilder, modelDefinition, domainBuilder.GetDefaultModelFields(ctx, modelDefinition))
 }
 
 func (domainBuilder *domainBuilder) NewGormRepositoryBuilder(ctx context.Context, repositoryDefinition *coredomaindefinition.Repository) Builder {
@@ -295,6 +274,14 @@ func (builder *domainBuilder) Build(ctx context.Context) (*model.Domain, error)
 
 	builder.addOrdering(ctx)
 	builder.addPagination(ctx)
+	builder.addEnums(ctx)
+	builder.addValueObjects(ctx)
+	builder.addDecimal(ctx)
+	builder.addCivilTime(ctx)
+	builder.addULID(ctx)
+	if builder.err != nil {
+		return nil, builder.err
+	}
 
 	port, err := builder.buildRelationGraph(ctx)
 	if err != nil {
@@ -532,6 +519,76 @@ func (builder *domainBuilder) addPagination(ctx context.Context) {
 	})
 }
 
+// addEnums adds a typed string with a constant per value for each enum in the model package
+func (builder *domainBuilder) addEnums(ctx context.Context) {
+	if builder.err != nil {
+		return
+	}
+
+	for _, enum := range builder.Definition.Enums {
+		enumType := &model.TypeDefinition{
+			Name: GetEnumName(ctx, enum),
+			Type: model.PrimitiveTypeString,
+		}
+		values := map[string]interface{}{}
+		for _, value := range enum.Values {
+			values[GetEnumValueName(ctx, enum, value)] = value
+		}
+
+		builder.Domain.Files = append(builder.Domain.Files, &model.File{
+			Name: GetEnumName(ctx, enum),
+			Pkg:  builder.GetModelPackage(),
+			Elements: []interface{}{
+				enumType,
+				&model.Enum{
+					Name:   GetEnumName(ctx, enum),
+					Type:   enumType,
+					Values: values,
+				},
+			},
+		})
+	}
+}
+
+// addValueObjects adds a struct for each value object in the model package, validate tags check nested fields
+func (builder *domainBuilder) addValueObjects(ctx context.Context) {
+	if builder.err != nil {
+		return
+	}
+
+	for _, valueObject := range builder.Definition.ValueObjects {
+		s := &model.Struct{
+			Name:   GetValueObjectName(ctx, valueObject),
+			Fields: []*model.Field{},
+		}
+		for _, f := range valueObject.Fields {
+			field, err := builder.FieldDefinitionToField(ctx, f)
+			if err != nil {
+				builder.err = err
+				return
+			}
+			validationTags, err := GetValidationTags(ctx, f)
+			if err != nil {
+				builder.err = err
+				return
+			}
+			if len(validationTags) > 0 {
+				field.Tags = append(field.Tags, &model.Tag{
+					Name:   "validate",
+					Values: validationTags,
+				})
+			}
+			s.Fields = append(s.Fields, field)
+		}
+
+		builder.Domain.Files = append(builder.Domain.Files, &model.File{
+			Name:     GetValueObjectName(ctx, valueObject),
+			Pkg:      builder.GetModelPackage(),
+			Elements: []interface{}{s},
+		})
+	}
+}
+
 func (domainBuilder *domainBuilder) addConsts(ctx context.Context) {
 	if domainBuilder.err != nil {
 		return
@@ -0,0 +1,171 @@
+package domainbuilder
+
+import (
+	"context"
+	"go/format"
+	"strings"
+	"testing"
+
+	"github.com/cleogithub/golem-common/pkg/stringtool"
+	"github.com/cleogithub/golem/coredomaindefinition"
+	"github.com/cleogithub/golem/goGeneration/domain/consts"
+	"github.com/cleogithub/golem/goGeneration/domain/internal/gopkgmanager"
+	"github.com/cleogithub/golem/goGeneration/domain/internal/stringifier"
+	"github.com/cleogithub/golem/goGeneration/domain/model"
+)
+
+const testPackage = "github.com/acme/shop"
+
+// newTestDomain returns a shop domain with http controllers, the generated files are in testPackage
+func newTestDomain(models ...*coredomaindefinition.Model) *coredomaindefinition.Domain {
+	return &coredomaindefinition.Domain{
+		Name:          "shop",
+		Configuration: &coredomaindefinition.DomainConfiguration{Package: testPackage},
+		Controllers:   coredomaindefinition.Controllers{Http: true},
+		Models:        models,
+	}
+}
+
+// generatedFiles are the formatted go files of a domain by path in the module, e.g. "domain/model/user",
+// and its javascript files by name
+type generatedFiles struct {
+	goFiles map[string]string
+	jsFiles map[string]string
+}
+
+// generate builds definition as the generation usecase does and formats every go file
+func generate(t *testing.T, definition *coredomaindefinition.Domain) *generatedFiles {
+	t.Helper()
+	ctx := context.Background()
+
+	if err := definition.Validate(); err != nil {
+		t.Fatalf("invalid definition: %v", err)
+	}
+	builder := NewDomainBuilder(ctx, definition, consts.DefaultModelFields)
+	for _, m := range definition.Models {
+		builder.WithModel(ctx, m)
+	}
+	for _, r := range definition.Relations {
+		builder.WithRelation(ctx, r)
+	}
+	for _, r := range definition.Repositories {
+		builder.WithRepository(ctx, r)
+	}
+	for _, c := range definition.CRUDs {
+		builder.WithCRUD(ctx, c)
+	}
+	for _, u := range definition.Usecases {
+		builder.WithUsecase(ctx, u)
+	}
+	domain, err := builder.Build(ctx)
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+
+	files := &generatedFiles{goFiles: map[string]string{}, jsFiles: domain.JSFiles}
+	for _, m := range domain.Models {
+		files.add(t, domain.Architecture.ModelPkg, m.Name, m)
+	}
+	for _, f := range domain.Files {
+		files.add(t, f.Pkg, f.Name, f)
+	}
+	return files
+}
+
+func (files *generatedFiles) add(t *testing.T, pkg *model.GoPkg, name string, elem interface{}) {
+	t.Helper()
+	ctx := context.Background()
+	pkgManager := &gopkgmanager.GoPkgManager{Pkg: pkg.ShortName}
+
+	var str string
+	var err error
+	switch elem := elem.(type) {
+	case *model.Struct:
+		str, err = stringifier.StringifyStructUsecase(ctx, pkgManager, elem)
+	case *model.File:
+		str, err = stringifier.StringifyFileUsecase(ctx, pkgManager, elem)
+	}
+	if err != nil {
+		t.Fatalf("stringify %s: %v", name, err)
+	}
+
+	path := strings.TrimPrefix(pkg.FullName, testPackage+"/") + "/" + stringtool.LowerFirstLetter(name)
+	formatted, err := format.Source([]byte(pkgManager.ToString() + consts.LN + str))
+	if err != nil {
+		t.Fatalf("format %s: %v\n%s", path, err, str)
+	}
+	files.goFiles[path] = string(formatted)
+}
+
+// goFile returns the go file at path, failing the test when it is not generated
+func (files *generatedFiles) goFile(t *testing.T, path string) string {
+	t.Helper()
+	content, ok := files.goFiles[path]
+	if !ok {
+		paths := []string{}
+		for p := range files.goFiles {
+			paths = append(paths, p)
+		}
+		t.Fatalf("%s is not generated, files: %v", path, paths)
+	}
+	return content
+}
+
+// jsFile returns the javascript file named name, failing the test when it is not generated
+func (files *generatedFiles) jsFile(t *testing.T, name string) string {
+	t.Helper()
+	content, ok := files.jsFiles[name]
+	if !ok {
+		t.Fatalf("%s.js is not generated", name)
+	}
+	return content
+}
+
+// goFunction returns the function of content starting with signature, failing the test when there is none
+func goFunction(t *testing.T, content string, signature string) string {
+	t.Helper()
+	start := strings.Index(content, signature)
+	if start < 0 {
+		t.Fatalf("expected a function %s in\n%s", signature, content)
+	}
+	end := strings.Index(content[start:], "\n}\n")
+	if end < 0 {
+		return content[start:]
+	}
+	return content[start : start+end+3]
+}
+
+// assertContains fails when content misses one of the snippets, tabs and spaces count
+func assertContains(t *testing.T, content string, snippets ...string) {
+	t.Helper()
+	for _, snippet := range snippets {
+		if !strings.Contains(content, snippet) {
+			t.Errorf("expected\n%s\nin\n%s", snippet, content)
+		}
+	}
+}
+
+// assertNotContains fails when content has one of the snippets
+func assertNotContains(t *testing.T, content string, snippets ...string) {
+	t.Helper()
+	for _, snippet := range snippets {
+		if strings.Contains(content, snippet) {
+			t.Errorf("unexpected\n%s\nin\n%s", snippet, content)
+		}
+	}
+}
+
+// withCRUD adds the repository and the CRUD of m to definition, without the actions of activable models
+func withCRUD(definition *coredomaindefinition.Domain, m *coredomaindefinition.Model) *coredomaindefinition.Domain {
+	active := coredomaindefinition.CRUDAction{Active: true}
+	definition.Repositories = append(definition.Repositories, &coredomaindefinition.Repository{On: m, TableName: m.Name + "s"})
+	definition.CRUDs = append(definition.CRUDs, &coredomaindefinition.CRUD{
+		On:     m,
+		Create: active,
+		Get:    active,
+		List:   active,
+		Update: active,
+		Delete: active,
+	})
+	return definition
+}
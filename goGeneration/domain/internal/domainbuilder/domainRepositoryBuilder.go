@@ -199,6 +199,7 @@ const (
 	REPOSITORY_WHERE_OPERATOR_NOT_EQUAL = "NOT_EQUAL"
 	REPOSITORY_WHERE_OPERATOR_IN        = "IN"
 	REPOSITORY_WHERE_OPERATOR_NOT_IN    = "NOT_IN"
+	REPOSITORY_WHERE_OPERATOR_CONTAINS  = "CONTAINS"
 	REPOSITORY_WHERE_OPERATOR_TYPE      = "WHERE_OPERATOR"
 )
 
@@ -215,6 +216,7 @@ var WHERE_OPERATOR = &model.Enum{
 		REPOSITORY_WHERE_OPERATOR_NOT_EQUAL: REPOSITORY_WHERE_OPERATOR_NOT_EQUAL,
 		REPOSITORY_WHERE_OPERATOR_IN:        REPOSITORY_WHERE_OPERATOR_IN,
 		REPOSITORY_WHERE_OPERATOR_NOT_IN:    REPOSITORY_WHERE_OPERATOR_NOT_IN,
+		REPOSITORY_WHERE_OPERATOR_CONTAINS:  REPOSITORY_WHERE_OPERATOR_CONTAINS,
 	},
 }
 
@@ -3,6 +3,7 @@ package domainbuilder
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/cleogithub/golem-common/pkg/merror"
@@ -376,29 +377,20 @@ func (builder *DomainUsecaseBuilder) WithUsecase(ctx context.Context, definition
 		Fields: []*model.Field{},
 	}
 	builder.structs = append(builder.structs, request)
-	mimeTypeValidation := ""
+	requestValidation := ""
 
+	args := []*coredomaindefinition.Field{}
 	for _, arg := range definition.Args {
-		t, err := builder.domainBuilder.TypeDefinitionToType(ctx, arg.Type)
+		field := arg.ToField()
+		args = append(args, field)
+		f, err := builder.domainBuilder.FieldDefinitionToField(ctx, field)
 		if err != nil {
 			builder.Err = err
 			return
 		}
-
-		f := &model.Field{
-			Name: GetFieldName(ctx, arg.Name),
-			Type: t,
-			Tags: []*model.Tag{
-				{
-					Name:   "json",
-					Values: []string{arg.Name},
-				},
-			},
-			JsonName: arg.Name,
-		}
 		request.Fields = append(request.Fields, f)
 
-		validationTags, err := GetValidationTags(ctx, arg.Validations)
+		validationTags, err := GetValidationTags(ctx, field)
 		if err != nil {
 			builder.Err = err
 			return
@@ -411,13 +403,34 @@ func (builder *DomainUsecaseBuilder) WithUsecase(ctx context.Context, definition
 		}
 
 		if arg.Type == coredomaindefinition.PrimitiveTypeFile {
-			mimeTypeValidation += fmt.Sprintf(
-				`if err := %s.%s.%s(ctx, []string{"image/png", "image/jpeg", "image/webp"}, %s.%s, "%s"); err != nil {`,
-				builder.validator.GetMethodName(), VALIDATOR_NAME, VALIDATOR_VALIDATE_MIME_TYPES_METHOD_NAME, REQUEST_PARAM_NAME, GetFieldName(ctx, arg.Name), arg.Name,
+			maxSize := GetFileMaxSize(ctx, field)
+			requestValidation += fmt.Sprintf("if len(%s.%s) > %d {", REQUEST_PARAM_NAME, GetFieldName(ctx, arg.Name), maxSize) + consts.LN
+			requestValidation += fmt.Sprintf(
+				`return nil, %s.%s.%s(ctx, "%s", "file is larger than %d bytes")`,
+				builder.validator.GetMethodName(), VALIDATOR_NAME, VALIDATOR_AS_VALIDATION_ERROR_METHOD_NAME, arg.Name, maxSize,
 			) + consts.LN
-			mimeTypeValidation += "return nil, err" + consts.LN
-			mimeTypeValidation += "}" + consts.LN
+			requestValidation += "}" + consts.LN
+
+			mimeTypes := []string{}
+			for _, mimeType := range GetFileMIMETypes(ctx, field) {
+				mimeTypes = append(mimeTypes, strconv.Quote(mimeType))
+			}
+			requestValidation += fmt.Sprintf(
+				`if err := %s.%s.%s(ctx, []string{%s}, %s.%s, "%s"); err != nil {`,
+				builder.validator.GetMethodName(), VALIDATOR_NAME, VALIDATOR_VALIDATE_MIME_TYPES_METHOD_NAME, strings.Join(mimeTypes, ", "), REQUEST_PARAM_NAME, GetFieldName(ctx, arg.Name), arg.Name,
+			) + consts.LN
+			requestValidation += "return nil, err" + consts.LN
+			requestValidation += "}" + consts.LN
+		}
+	}
+
+	for _, validation := range definition.Validations {
+		str, err := GetFieldsValidationCheck(ctx, validation, args, REQUEST_PARAM_NAME, builder.validator.GetMethodName())
+		if err != nil {
+			builder.Err = err
+			return
 		}
+		requestValidation += str
 	}
 
 	response := &model.Struct{
@@ -427,23 +440,11 @@ func (builder *DomainUsecaseBuilder) WithUsecase(ctx context.Context, definition
 	builder.structs = append(builder.structs, response)
 
 	for _, res := range definition.Results {
-		t, err := builder.domainBuilder.TypeDefinitionToType(ctx, res.Type)
+		f, err := builder.domainBuilder.FieldDefinitionToField(ctx, res.ToField())
 		if err != nil {
 			builder.Err = err
 			return
 		}
-
-		f := &model.Field{
-			Name: GetFieldName(ctx, res.Name),
-			Type: t,
-			Tags: []*model.Tag{
-				{
-					Name:   "json",
-					Values: []string{res.Name},
-				},
-			},
-			JsonName: res.Name,
-		}
 		response.Fields = append(response.Fields, f)
 	}
 
@@ -485,7 +486,7 @@ func (builder *DomainUsecaseBuilder) WithUsecase(ctx context.Context, definition
 			str += "return nil, err" + consts.LN
 			str += "}" + consts.LN
 
-			str += mimeTypeValidation
+			str += requestValidation
 
 			str += fmt.Sprintf("return %s.%s.%s(ctx, %s)",
 				builder.validator.GetMethodName(), VALIDATOR_USECASE_FIELD_NAME, GetUsecaseMethodName(ctx, definition.Name), REQUEST_PARAM_NAME,
@@ -597,6 +598,8 @@ func (builder *DomainUsecaseBuilder) Build(ctx context.Context) error {
 	}
 
 	builder.addValidator(ctx)
+	builder.addValidationErrors(ctx)
+	builder.addDefaultValidator(ctx)
 	builder.addSecurity(ctx)
 	builder.addErrors(ctx)
 
@@ -618,6 +621,12 @@ const (
 	VALIDATOR_AS_VALIDATION_ERROR_METHOD_NAME = "AsValidationError"
 )
 
+// VALIDATOR_COMMENT is the contract of the Validator port, field names are json paths, e.g. "address.zipCode"
+const VALIDATOR_COMMENT = VALIDATOR_NAME + ` validates the requests of the usecases, DefaultValidator is an implementation.
+The field arguments are the json names of the invalid fields, e.g. "shopId" and not "ShopId", as reported to the clients.
+Errors recognized by IsValidationError are answered with the status 422 and the payload of ToValidationErrors,
+return ValidationErrors to report the invalid fields.`
+
 func (builder *DomainUsecaseBuilder) addSecurity(ctx context.Context) {
 	if builder.Err != nil {
 		return
@@ -676,10 +685,12 @@ func (builder *DomainUsecaseBuilder) addValidator(ctx context.Context) {
 	}
 
 	validator := &model.Interface{
-		Name: VALIDATOR_NAME,
+		Comment: VALIDATOR_COMMENT,
+		Name:    VALIDATOR_NAME,
 		Methods: []*model.Function{
 			{
-				Name: VALIDATOR_VALIDATE_METHOD_NAME,
+				Comment: "Validate checks the validate tags of request",
+				Name:    VALIDATOR_VALIDATE_METHOD_NAME,
 				Args: []*model.Param{
 					{
 						Name: "ctx",
@@ -702,7 +713,8 @@ func (builder *DomainUsecaseBuilder) addValidator(ctx context.Context) {
 				},
 			},
 			{
-				Name: VALIDATOR_IS_VALIDATION_ERROR_METHOD_NAME,
+				Comment: "IsValidationError reports whether err is a validation error, answered with the status 422",
+				Name:    VALIDATOR_IS_VALIDATION_ERROR_METHOD_NAME,
 				Args: []*model.Param{
 					{
 						Name: "ctx",
@@ -725,7 +737,8 @@ func (builder *DomainUsecaseBuilder) addValidator(ctx context.Context) {
 				},
 			},
 			{
-				Name: VALIDATOR_NEW_REFERENCE_ERROR_METHOD_NAME,
+				Comment: "NewReferenceError reports that reference, the json name of a relation id, does not reference an existing element",
+				Name:    VALIDATOR_NEW_REFERENCE_ERROR_METHOD_NAME,
 				Args: []*model.Param{
 					{
 						Name: "ctx",
@@ -748,7 +761,8 @@ func (builder *DomainUsecaseBuilder) addValidator(ctx context.Context) {
 				},
 			},
 			{
-				Name: VALIDATOR_NEW_UNIQUE_ERROR_METHOD_NAME,
+				Comment: "NewUniqueError reports that the value of field, a json name, is already used",
+				Name:    VALIDATOR_NEW_UNIQUE_ERROR_METHOD_NAME,
 				Args: []*model.Param{
 					{
 						Name: "ctx",
@@ -771,7 +785,8 @@ func (builder *DomainUsecaseBuilder) addValidator(ctx context.Context) {
 				},
 			},
 			{
-				Name: VALIDATOR_VALIDATE_MIME_TYPES_METHOD_NAME,
+				Comment: "ValidateMimeTypes checks that bytes, the file of field, is of one of mimeTypes",
+				Name:    VALIDATOR_VALIDATE_MIME_TYPES_METHOD_NAME,
 				Args: []*model.Param{
 					{
 						Name: "ctx",
@@ -804,8 +819,8 @@ func (builder *DomainUsecaseBuilder) addValidator(ctx context.Context) {
 				},
 			},
 			{
-
-				Name: VALIDATOR_AS_VALIDATION_ERROR_METHOD_NAME,
+				Comment: "AsValidationError reports that field is invalid with message",
+				Name:    VALIDATOR_AS_VALIDATION_ERROR_METHOD_NAME,
 				Args: []*model.Param{
 					{
 						Name: "ctx",
@@ -0,0 +1,37 @@
+package domainbuilder
+
+import (
+	"testing"
+
+	"github.com/cleogithub/golem/coredomaindefinition"
+)
+
+func TestFileArgGeneration(t *testing.T) {
+	definition := newTestDomain()
+	definition.Usecases = []*coredomaindefinition.Usecase{{
+		Name: "upload",
+		Args: []*coredomaindefinition.Param{
+			{Name: "logo", Type: coredomaindefinition.PrimitiveTypeFile, Validations: []*coredomaindefinition.Validation{
+				{Rule: coredomaindefinition.ValidationRuleMIMETypes, Value: []interface{}{"image/png", "image/svg+xml"}},
+				{Rule: coredomaindefinition.ValidationRuleMaxSize, Value: "2MB"},
+			}},
+			{Name: "doc", Type: coredomaindefinition.PrimitiveTypeFile},
+		},
+	}}
+
+	files := generate(t, definition)
+
+	// the controller reads at most the sum of the file limits
+	assertContains(t, files.goFile(t, "adapter/controller/httpadapter/shopHttpController"),
+		"r.ParseMultipartForm(12582912)",
+		"if header0.Size > 2097152 {\n\t\tw.WriteHeader(http.StatusRequestEntityTooLarge)",
+		"if header1.Size > 10485760 {",
+	)
+	// files without rules get the default limits
+	assertContains(t, files.goFile(t, "domain/usecase/shopUsecaseValidator"),
+		"if len(request.Logo) > 2097152 {",
+		`ValidateMimeTypes(ctx, []string{"image/png", "image/svg+xml"}, request.Logo, "logo")`,
+		"if len(request.Doc) > 10485760 {",
+		`ValidateMimeTypes(ctx, []string{"image/png", "image/jpeg", "image/webp"}, request.Doc, "doc")`,
+	)
+}
@@ -0,0 +1,58 @@
+package domainbuilder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cleogithub/golem-common/pkg/stringtool"
+	"github.com/cleogithub/golem/coredomaindefinition"
+)
+
+func GetEnumName(ctx context.Context, enum *coredomaindefinition.Enum) string {
+	return stringtool.UpperFirstLetter(enum.Name)
+}
+
+// GetEnumValueName returns the go constant name of an enum value, e.g. "in-progress" of OrderStatus gives "OrderStatusInProgress"
+func GetEnumValueName(ctx context.Context, enum *coredomaindefinition.Enum, value string) string {
+	return coredomaindefinition.GetEnumValueConstName(enum, value)
+}
+
+// GetJSEnumValueName returns the key of an enum value in the javascript frozen object, e.g. "in-progress" gives "IN_PROGRESS"
+func GetJSEnumValueName(ctx context.Context, value string) string {
+	return coredomaindefinition.GetEnumValueJSKey(value)
+}
+
+// GetEnum returns the enum of a type, or of the elements of an array type, nil otherwise
+func GetEnum(ctx context.Context, t coredomaindefinition.Type) *coredomaindefinition.Enum {
+	switch t := t.(type) {
+	case *coredomaindefinition.Enum:
+		return t
+	case *coredomaindefinition.Array:
+		return GetEnum(ctx, t.Type)
+	}
+	return nil
+}
+
+// GetEnumValidationTags returns the validate tags restricting a value to the enum values, diving into arrays
+func GetEnumValidationTags(ctx context.Context, t coredomaindefinition.Type) []string {
+	enum := GetEnum(ctx, t)
+	if enum == nil {
+		return nil
+	}
+
+	tags := []string{}
+	if _, ok := t.(*coredomaindefinition.Array); ok {
+		tags = append(tags, "dive")
+	}
+	return append(tags, "oneof="+strings.Join(enum.Values, " "))
+}
+
+// GetEnumCheckConstraint returns the gorm check constraint restricting the column to the enum values
+func GetEnumCheckConstraint(ctx context.Context, column string, enum *coredomaindefinition.Enum) string {
+	values := []string{}
+	for _, value := range enum.Values {
+		values = append(values, "'"+value+"'")
+	}
+	return fmt.Sprintf("check:%s IN (%s)", column, strings.Join(values, ","))
+}
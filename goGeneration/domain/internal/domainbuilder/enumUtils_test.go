@@ -0,0 +1,34 @@
+package domainbuilder
+
+import (
+	"testing"
+
+	"github.com/cleogithub/golem/coredomaindefinition"
+)
+
+func TestEnumGeneration(t *testing.T) {
+	status := &coredomaindefinition.Enum{Name: "status", Values: []string{"pending", "1st", "in-progress"}}
+	shop := coredomaindefinition.NewModel("shop")
+	shop.Fields = []*coredomaindefinition.Field{{Name: "status", Type: status}}
+	definition := withCRUD(newTestDomain(shop), shop)
+	definition.Enums = []*coredomaindefinition.Enum{status}
+
+	files := generate(t, definition)
+
+	assertContains(t, files.goFile(t, "domain/model/status"),
+		"type Status string",
+		`StatusPending    Status = "pending"`,
+		`Status1st        Status = "1st"`,
+		`StatusInProgress Status = "in-progress"`,
+	)
+	assertContains(t, files.goFile(t, "domain/model/shop"), "Status    Status    `json:\"status\"`")
+	assertContains(t, files.goFile(t, "adapter/repository/gormadapter/shop"), "check:status IN ('pending','1st','in-progress')")
+	// a zero enum is not provided, a provided one must be a value
+	assertContains(t, files.goFile(t, "domain/usecase/structs"), `validate:"omitempty,oneof=pending 1st in-progress"`)
+	assertContains(t, files.jsFile(t, "enums"),
+		"export const Status = Object.freeze({",
+		"\tPENDING: 'pending',",
+		"\t'1ST': '1st',",
+		"\tIN_PROGRESS: 'in-progress',",
+	)
+}
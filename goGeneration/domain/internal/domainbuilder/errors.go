@@ -25,13 +25,22 @@ var (
 	// ErrValidationValueExpectedType is returned when the validation value is used but value is not of the expected type
 	ErrValidationValueExpectedType = errors.New("validation {{ rule }} expected value of type {{ type }}")
 
-	// ErrUnexpectedValidationRule is returned when the validation value is used but value is not of the expected type
+	// ErrUnexpectedValidationRule is returned when a validation rule can not be turned into a validate tag
 	ErrUnexpectedValidationRule = errors.New("unexpected validation rule: {{ rule }}")
 
 	ErrRelationDoesNotBelongToModel = errors.New("relation does not belong to model")
 
 	// ErrModelNotActivable is returned when the model is not activable and an action is performed on it depending on active element
 	ErrModelNotActivable = errors.New("model {model} and his dependency relations is not activable")
+
+	// ErrInvalidTemplate is returned when a template override can not be parsed
+	ErrInvalidTemplate = errors.New("invalid template {template}: {error}")
+
+	// ErrUnknownTemplateField is returned when a template override references a field missing in its data
+	ErrUnknownTemplateField = errors.New("template {template} references unknown field {field}, available fields are {fields}")
+
+	// ErrFieldNotFound is returned when a validation on several fields references a missing field
+	ErrFieldNotFound = errors.New("field '{field}' not found")
 )
 
 func NewErrUnknownType(t string) error {
@@ -74,3 +83,18 @@ func NewErrRelationNotActivable(model string) error {
 	str := strings.Replace(ErrModelNotActivable.Error(), "{model}", model, 1)
 	return errors.New(str)
 }
+
+func NewErrInvalidTemplate(template string, err error) error {
+	str := strings.Replace(ErrInvalidTemplate.Error(), "{template}", template, 1)
+	return errors.New(strings.Replace(str, "{error}", err.Error(), 1))
+}
+
+func NewErrUnknownTemplateField(template string, field string, fields []string) error {
+	str := strings.Replace(ErrUnknownTemplateField.Error(), "{template}", template, 1)
+	str = strings.Replace(str, "{field}", field, 1)
+	return errors.New(strings.Replace(str, "{fields}", strings.Join(fields, ", "), 1))
+}
+
+func NewErrFieldNotFound(field string) error {
+	return errors.New(strings.Replace(ErrFieldNotFound.Error(), "{field}", field, 1))
+}
@@ -0,0 +1,104 @@
+package domainbuilder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cleogithub/golem/coredomaindefinition"
+	"github.com/cleogithub/golem/goGeneration/domain/consts"
+	"github.com/cleogithub/golem/goGeneration/domain/model"
+)
+
+const (
+	EXTERNAL_TYPE_TO_COLUMN_SUFFIX   = "ToColumn"
+	EXTERNAL_TYPE_FROM_COLUMN_SUFFIX = "FromColumn"
+)
+
+// GetExternalTypePkg returns the package declaring an external type, imported by the files using it
+func GetExternalTypePkg(ctx context.Context, t *coredomaindefinition.ExternalType) *model.GoPkg {
+	return &model.GoPkg{
+		Alias:     t.GetPackageName(),
+		ShortName: t.GetPackageName(),
+		FullName:  t.Package,
+	}
+}
+
+// GetExternalTypeReference returns the go type of an external type
+func GetExternalTypeReference(ctx context.Context, t *coredomaindefinition.ExternalType) *model.PkgReference {
+	return &model.PkgReference{
+		Pkg:       GetExternalTypePkg(ctx, t),
+		Reference: &model.ExternalType{Type: t.Name},
+	}
+}
+
+// GetExternalTypeColumnConverter returns the name of the gorm adapter function converting an optional external type to its column
+func GetExternalTypeColumnConverter(ctx context.Context, t *coredomaindefinition.ExternalType, toColumn bool) string {
+	if toColumn {
+		return t.Name + EXTERNAL_TYPE_TO_COLUMN_SUFFIX
+	}
+	return t.Name + EXTERNAL_TYPE_FROM_COLUMN_SUFFIX
+}
+
+// GetExternalTypeConversion returns the expression converting value from the model to the gorm column of an external type with converters, or back.
+// Optional values are pointers, converted through the functions added by addExternalTypeConverters.
+func GetExternalTypeConversion(ctx context.Context, t *coredomaindefinition.ExternalType, optional bool, toColumn bool, value string) string {
+	if optional {
+		return fmt.Sprintf("%s(%s)", GetExternalTypeColumnConverter(ctx, t, toColumn), value)
+	}
+	if toColumn {
+		return fmt.Sprintf("%s.%s(%s)", t.GetPackageName(), t.ToRepresentation, value)
+	}
+	return fmt.Sprintf("%s.%s(%s)", t.GetPackageName(), t.FromRepresentation, value)
+}
+
+// addExternalTypeConverters adds to the gorm adapter the nil safe converters of the optional fields of each external type with converters
+func (builder *GormDomainRepositoryBuilder) addExternalTypeConverters(ctx context.Context) {
+	if builder.Err != nil {
+		return
+	}
+
+	elements := []interface{}{}
+	for _, t := range builder.DomainBuilder.Definition.ExternalTypes {
+		if !t.HasConverters() {
+			continue
+		}
+
+		representation, err := builder.DomainBuilder.TypeDefinitionToType(ctx, t.GetRepresentation())
+		if err != nil {
+			builder.Err = err
+			return
+		}
+		pkg := GetExternalTypePkg(ctx, t)
+		for _, toColumn := range []bool{true, false} {
+			from, to := model.Type(GetExternalTypeReference(ctx, t)), representation
+			converter := t.ToRepresentation
+			if !toColumn {
+				from, to = to, from
+				converter = t.FromRepresentation
+			}
+
+			elements = append(elements, &model.Function{
+				Name:    GetExternalTypeColumnConverter(ctx, t, toColumn),
+				Args:    []*model.Param{{Name: "value", Type: &model.PointerType{Type: from}}},
+				Results: []*model.Param{{Type: &model.PointerType{Type: to}}},
+				Content: func() (string, []*model.GoPkg) {
+					str := "if value == nil {" + consts.LN
+					str += "return nil" + consts.LN
+					str += "}" + consts.LN
+					str += fmt.Sprintf("converted := %s.%s(*value)", pkg.Alias, converter) + consts.LN
+					str += "return &converted"
+					return str, []*model.GoPkg{pkg}
+				},
+			})
+		}
+	}
+	if len(elements) == 0 {
+		return
+	}
+
+	builder.DomainBuilder.Domain.Files = append(builder.DomainBuilder.Domain.Files, &model.File{
+		Name:     "externalTypes",
+		Pkg:      builder.DomainBuilder.GetGormAdapterPackage(),
+		Elements: elements,
+	})
+}
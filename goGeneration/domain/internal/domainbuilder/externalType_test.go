@@ -0,0 +1,48 @@
+package domainbuilder
+
+import (
+	"testing"
+
+	"github.com/cleogithub/golem/coredomaindefinition"
+)
+
+func TestExternalTypeGeneration(t *testing.T) {
+	addr := &coredomaindefinition.ExternalType{Package: "net/netip", Name: "Addr", ToRepresentation: "AddrToString", FromRepresentation: "AddrFromString"}
+	duration := &coredomaindefinition.ExternalType{Package: "github.com/acme/types/v2", Name: "Duration", Representation: coredomaindefinition.PrimitiveTypeInt}
+	shop := coredomaindefinition.NewModel("shop")
+	shop.Fields = []*coredomaindefinition.Field{
+		{Name: "ip", Type: addr},
+		{Name: "lastIp", Type: addr, Optional: true},
+		{Name: "delay", Type: duration, Optional: true},
+	}
+	definition := withCRUD(newTestDomain(shop), shop)
+	definition.ExternalTypes = []*coredomaindefinition.ExternalType{addr, duration}
+
+	files := generate(t, definition)
+
+	model := files.goFile(t, "domain/model/shop")
+	assertContains(t, model,
+		"\"github.com/acme/types/v2\"",
+		"\"net/netip\"",
+		"Ip        netip.Addr      `json:\"ip\"`",
+		"LastIp    *netip.Addr     `json:\"lastIp,omitempty\"`",
+		"Delay     *types.Duration `json:\"delay,omitempty\"`",
+	)
+
+	// a type with converters is stored as its representation, other ones as they are
+	gormModel := files.goFile(t, "adapter/repository/gormadapter/shop")
+	assertContains(t, gormModel,
+		"Ip        string          `gorm:\"column:ip\"`",
+		"LastIp    *string         `gorm:\"column:last_ip\"`",
+		"Delay     *types.Duration `gorm:\"column:delay\"`",
+		"Ip:        netip.AddrFromString(gormModel.Ip),",
+		"LastIp:    AddrFromColumn(gormModel.LastIp),",
+		"Ip:        netip.AddrToString(gormModel.Ip),",
+		"LastIp:    AddrToColumn(gormModel.LastIp),",
+	)
+	assertContains(t, files.goFile(t, "adapter/repository/gormadapter/externalTypes"),
+		"func AddrToColumn(value *netip.Addr) *string {",
+		"func AddrFromColumn(value *string) *netip.Addr {",
+	)
+	assertContains(t, files.jsFile(t, "entities"), "\tip // string\n", "\tdelay // number\n")
+}
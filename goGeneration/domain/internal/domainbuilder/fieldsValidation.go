@@ -0,0 +1,147 @@
+package domainbuilder
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cleogithub/golem/coredomaindefinition"
+	"github.com/cleogithub/golem/goGeneration/domain/consts"
+)
+
+// fieldsComparisons are the go operator failing each comparison rule and its default message
+var fieldsComparisons = map[coredomaindefinition.FieldsValidationRule]struct {
+	failing string
+	message string
+}{
+	coredomaindefinition.FieldsValidationRuleGTField:  {"<=", "must be greater than"},
+	coredomaindefinition.FieldsValidationRuleGTEField: {"<", "must be greater than or equal to"},
+	coredomaindefinition.FieldsValidationRuleLTField:  {">=", "must be less than"},
+	coredomaindefinition.FieldsValidationRuleLTEField: {">", "must be less than or equal to"},
+	coredomaindefinition.FieldsValidationRuleEQField:  {"!=", "must be equal to"},
+	coredomaindefinition.FieldsValidationRuleNEField:  {"==", "must be different from"},
+}
+
+// GetFieldsValidationCheck returns the code returning a validation error of receiver's validator when validation fails on the fields of target, e.g. request or entity
+func GetFieldsValidationCheck(ctx context.Context, validation *coredomaindefinition.FieldsValidation, fields []*coredomaindefinition.Field, target string, receiver string) (string, error) {
+	found := []*coredomaindefinition.Field{}
+	for _, name := range validation.Fields {
+		i := slices.IndexFunc(fields, func(f *coredomaindefinition.Field) bool { return f.Name == name })
+		if i < 0 {
+			return "", NewErrFieldNotFound(name)
+		}
+		found = append(found, fields[i])
+	}
+	if len(found) == 0 {
+		return "", NewErrFieldNotFound(strings.Join(validation.Fields, ", "))
+	}
+
+	condition, message := "", validation.Message
+	if validation.Rule == coredomaindefinition.FieldsValidationRuleAnyRequired {
+		unset := []string{}
+		for _, f := range found {
+			unset = append(unset, getFieldUnsetCondition(ctx, f, target+"."+GetFieldName(ctx, f.Name)))
+		}
+		condition = strings.Join(unset, " && ")
+		if message == "" {
+			last := len(validation.Fields) - 1
+			message = strings.Join(validation.Fields[:last], ", ") + " or " + validation.Fields[last] + " is required"
+		}
+	} else {
+		comparison, ok := fieldsComparisons[validation.Rule]
+		if !ok || len(found) != 2 {
+			return "", NewErrUnexpectedValidationRule(string(validation.Rule))
+		}
+		// the comparison is skipped when a side is not set, required is the rule of missing fields
+		a, guards := getFieldComparedValue(ctx, found[0], target+"."+GetFieldName(ctx, found[0].Name), nil)
+		b, guards := getFieldComparedValue(ctx, found[1], target+"."+GetFieldName(ctx, found[1].Name), guards)
+		condition = strings.Join(append(guards, getFieldsFailingComparison(found[0].Type, validation.Rule, comparison.failing, a, b)), " && ")
+		if message == "" {
+			message = fmt.Sprintf("%s %s", comparison.message, found[1].Name)
+		}
+	}
+
+	str := fmt.Sprintf("if %s {", condition) + consts.LN
+	str += fmt.Sprintf(`return nil, %s.%s.%s(ctx, "%s", %s)`, receiver, VALIDATOR_NAME, VALIDATOR_AS_VALIDATION_ERROR_METHOD_NAME, found[0].Name, strconv.Quote(message)) + consts.LN
+	str += "}" + consts.LN
+	return str, nil
+}
+
+// getFieldComparedValue returns the value of f compared by a rule, dereferenced when optional,
+// and adds to guards the conditions true when target is set: not nil and, for text, dates and datetimes, not empty
+func getFieldComparedValue(ctx context.Context, f *coredomaindefinition.Field, target string, guards []string) (string, []string) {
+	value := target
+	if f.Optional {
+		guards = append(guards, target+" != nil")
+		value = "*" + target
+	}
+	switch f.Type {
+	case coredomaindefinition.PrimitiveTypeDateTime:
+		// the methods of time.Time are called on pointers too
+		return value, append(guards, fmt.Sprintf("!%s.IsZero()", target))
+	case coredomaindefinition.PrimitiveTypeString, coredomaindefinition.PrimitiveTypeDate, coredomaindefinition.PrimitiveTypeTime,
+		coredomaindefinition.PrimitiveTypeDecimal:
+		return value, append(guards, fmt.Sprintf(`%s != ""`, value))
+	}
+	return value, guards
+}
+
+// getFieldsFailingComparison returns the condition true when a and b, values of type t, fail the comparison rule
+func getFieldsFailingComparison(t coredomaindefinition.Type, rule coredomaindefinition.FieldsValidationRule, failing string, a string, b string) string {
+	if t == coredomaindefinition.PrimitiveTypeDateTime || t == coredomaindefinition.PrimitiveTypeDecimal {
+		// dereferenced values are parenthesized to call their methods
+		if strings.HasPrefix(a, "*") {
+			a = "(" + a + ")"
+		}
+		if strings.HasPrefix(b, "*") {
+			b = "(" + b + ")"
+		}
+	}
+	switch t {
+	case coredomaindefinition.PrimitiveTypeDateTime:
+		switch rule {
+		case coredomaindefinition.FieldsValidationRuleGTField:
+			return fmt.Sprintf("!%s.After(%s)", a, b)
+		case coredomaindefinition.FieldsValidationRuleGTEField:
+			return fmt.Sprintf("%s.Before(%s)", a, b)
+		case coredomaindefinition.FieldsValidationRuleLTField:
+			return fmt.Sprintf("!%s.Before(%s)", a, b)
+		case coredomaindefinition.FieldsValidationRuleLTEField:
+			return fmt.Sprintf("%s.After(%s)", a, b)
+		case coredomaindefinition.FieldsValidationRuleEQField:
+			return fmt.Sprintf("!%s.Equal(%s)", a, b)
+		}
+		return fmt.Sprintf("%s.Equal(%s)", a, b)
+	case coredomaindefinition.PrimitiveTypeDecimal:
+		// decimals are compared by value, 1.50 equals 1.5
+		return fmt.Sprintf("%s.Rat().Cmp(%s.Rat()) %s 0", a, b, failing)
+	}
+	// dates and times of day are strings in their layout, so they are ordered as strings
+	return fmt.Sprintf("%s %s %s", a, failing, b)
+}
+
+// getFieldUnsetCondition returns the condition true when target, the value of f, is not set
+func getFieldUnsetCondition(ctx context.Context, f *coredomaindefinition.Field, target string) string {
+	switch f.Type.(type) {
+	case *coredomaindefinition.Array, *coredomaindefinition.Map:
+		return fmt.Sprintf("len(%s) == 0", target)
+	}
+	switch f.Type {
+	case coredomaindefinition.PrimitiveTypeBytes, coredomaindefinition.PrimitiveTypeFile, coredomaindefinition.PrimitiveTypeJSON:
+		return fmt.Sprintf("len(%s) == 0", target)
+	}
+	if f.Optional {
+		return fmt.Sprintf("%s == nil", target)
+	}
+	switch f.Type {
+	case coredomaindefinition.PrimitiveTypeInt, coredomaindefinition.PrimitiveTypeFloat, coredomaindefinition.PrimitiveTypeByte:
+		return fmt.Sprintf("%s == 0", target)
+	case coredomaindefinition.PrimitiveTypeBool:
+		return "!" + target
+	case coredomaindefinition.PrimitiveTypeDateTime:
+		return target + ".IsZero()"
+	}
+	return fmt.Sprintf(`%s == ""`, target)
+}
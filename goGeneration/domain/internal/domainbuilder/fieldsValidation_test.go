@@ -0,0 +1,50 @@
+package domainbuilder
+
+import (
+	"testing"
+
+	"github.com/cleogithub/golem/coredomaindefinition"
+)
+
+func TestFieldsValidationGeneration(t *testing.T) {
+	shop := coredomaindefinition.NewModel("shop")
+	shop.Fields = []*coredomaindefinition.Field{
+		{Name: "opensAt", Type: coredomaindefinition.PrimitiveTypeDate},
+		{Name: "closesAt", Type: coredomaindefinition.PrimitiveTypeDate, Optional: true},
+		{Name: "email", Type: coredomaindefinition.PrimitiveTypeString, Optional: true},
+		{Name: "phone", Type: coredomaindefinition.PrimitiveTypeString, Optional: true},
+	}
+	shop.Validations = []*coredomaindefinition.FieldsValidation{
+		{Rule: coredomaindefinition.FieldsValidationRuleGTField, Fields: []string{"closesAt", "opensAt"}},
+		{Rule: coredomaindefinition.FieldsValidationRuleAnyRequired, Fields: []string{"email", "phone"}, Message: "a contact is required"},
+	}
+	definition := withCRUD(newTestDomain(shop), shop)
+	definition.Usecases = []*coredomaindefinition.Usecase{{
+		Name: "book",
+		Args: []*coredomaindefinition.Param{
+			{Name: "from", Type: coredomaindefinition.PrimitiveTypeInt},
+			{Name: "to", Type: coredomaindefinition.PrimitiveTypeInt, Optional: true},
+		},
+		Validations: []*coredomaindefinition.FieldsValidation{
+			{Rule: coredomaindefinition.FieldsValidationRuleLTEField, Fields: []string{"from", "to"}},
+		},
+	}}
+
+	files := generate(t, definition)
+
+	// the models are checked before they are sent to the repository, empty and nil values are skipped
+	crud := files.goFile(t, "domain/usecase/shopUsecaseCRUD")
+	checks := []string{
+		"if entity.ClosesAt != nil && *entity.ClosesAt != \"\" && entity.OpensAt != \"\" && *entity.ClosesAt <= entity.OpensAt {\n\t\treturn nil, crud.Validator.AsValidationError(ctx, \"closesAt\", \"must be greater than opensAt\")\n\t}",
+		"if entity.Email == nil && entity.Phone == nil {\n\t\treturn nil, crud.Validator.AsValidationError(ctx, \"email\", \"a contact is required\")\n\t}\n\tentity, err := crud.DomainRepository.",
+	}
+	assertContains(t, goFunction(t, crud, "func (crud *ShopUsecaseCRUD) CreateShop("), checks...)
+	assertContains(t, goFunction(t, crud, "func (crud *ShopUsecaseCRUD) UpdateShop("), checks...)
+
+	// the args of a usecase are checked after their own validations
+	validator := files.goFile(t, "domain/usecase/shopUsecaseValidator")
+	assertContains(t, goFunction(t, validator, "func (shopUsecaseValidator *ShopUsecaseValidator) Book("),
+		"if err := shopUsecaseValidator.Validator.Validate(ctx, request); err != nil {\n\t\treturn nil, err\n\t}\n\tif request.To != nil && request.From > *request.To {\n\t\treturn nil, shopUsecaseValidator.Validator.AsValidationError(ctx, \"from\", \"must be less than or equal to to\")\n\t}",
+	)
+	assertNotContains(t, goFunction(t, validator, "func (shopUsecaseValidator *ShopUsecaseValidator) CreateShop("), "AsValidationError")
+}
@@ -154,6 +154,8 @@ func (builder *GormDomainRepositoryBuilder) Build(ctx context.Context) error {
 	}
 
 	builder.addTransaction(ctx)
+	builder.addPostgresArraySerializer(ctx)
+	builder.addExternalTypeConverters(ctx)
 
 	gormDomainRepo := &model.Struct{
 		Name:       GetGormDomainRepositoryName(ctx, builder.DomainBuilder.Definition),
@@ -269,39 +271,75 @@ func (builder *GormDomainRepositoryBuilder) Build(ctx context.Context) error {
 			return str, nil
 		},
 	}
-	valueToGormValue := &model.Function{
-		Name: VALUE_TO_GORM_VALUE,
+	repoAlias := builder.DomainBuilder.GetRepositoryPackage().Alias
+	whereToGorm := &model.Function{
+		Name: WHERE_TO_GORM,
 		Args: []*model.Param{
 			{
-				Name: "value",
-				Type: model.PrimitiveTypeInterface,
+				Name: GORM_REQUEST_NAME,
+				Type: &model.PointerType{
+					Type: &model.PkgReference{
+						Pkg: consts.CommonPkgs["gorm"],
+						Reference: &model.ExternalType{
+							Type: "DB",
+						},
+					},
+				},
+			},
+			{
+				Name: "column",
+				Type: model.PrimitiveTypeString,
+			},
+			{
+				Name: "where",
+				Type: &model.PointerType{
+					Type: &model.PkgReference{
+						Pkg: builder.DomainBuilder.GetRepositoryPackage(),
+						Reference: &model.ExternalType{
+							Type: REPOSITORY_WHERE,
+						},
+					},
+				},
 			},
 		},
 		Results: []*model.Param{
 			{
-				Type: model.PrimitiveTypeString,
+				Type: &model.PointerType{
+					Type: &model.PkgReference{
+						Pkg: consts.CommonPkgs["gorm"],
+						Reference: &model.ExternalType{
+							Type: "DB",
+						},
+					},
+				},
 			},
 		},
 		Content: func() (string, []*model.GoPkg) {
-			str := "v := reflect.TypeOf(value).Kind()" + consts.LN
-			str += "switch  v {" + consts.LN
-			str += "case reflect.Array, reflect.Slice:" + consts.LN
-			str += `str := "["` + consts.LN
-			str += "for i := 0; i < reflect.ValueOf(value).Len(); i++ {" + consts.LN
-			str += fmt.Sprintf("str += %s(reflect.ValueOf(value).Index(i).Interface())", VALUE_TO_GORM_VALUE) + consts.LN
-			str += `str += ","` + consts.LN
+			str := fmt.Sprintf("if where.%s != %s.%s {", REPOSITORY_WHERE_OPERATOR, repoAlias, REPOSITORY_WHERE_OPERATOR_CONTAINS) + consts.LN
+			str += fmt.Sprintf(`return %s.Where(fmt.Sprintf("%%s %%s ?", column, %s(where.%s)), where.%s)`, GORM_REQUEST_NAME, OPERATOR_TO_GORM_OPERATOR, REPOSITORY_WHERE_OPERATOR, REPOSITORY_WHERE_VALUE) + consts.LN
 			str += "}" + consts.LN
-			str += `str = strings.TrimSuffix(str, ",") + "]"` + consts.LN
-			str += `return str` + consts.LN
-			str += "case reflect.String:" + consts.LN
-			str += `return fmt.Sprintf("\"%+v\"", v)` + consts.LN
-			str += "default:" + consts.LN
-			str += `return fmt.Sprintf("%+v", v)` + consts.LN
-			str += "}"
+			if builder.DomainBuilder.GetDialect(ctx) == coredomaindefinition.DialectPostgres {
+				str += fmt.Sprintf(`return %s.Where(fmt.Sprintf("? = ANY(%%s)", column), where.%s)`, GORM_REQUEST_NAME, REPOSITORY_WHERE_VALUE)
+				return str, []*model.GoPkg{consts.CommonPkgs["fmt"], builder.DomainBuilder.GetRepositoryPackage()}
+			}
+
+			str += "// arrays are JSON columns, the element is searched in the serialized array" + consts.LN
+			str += fmt.Sprintf("element, err := json.Marshal(where.%s)", REPOSITORY_WHERE_VALUE) + consts.LN
+			str += "if err != nil {" + consts.LN
+			str += fmt.Sprintf("%s.AddError(err)", GORM_REQUEST_NAME) + consts.LN
+			str += fmt.Sprintf("return %s", GORM_REQUEST_NAME) + consts.LN
+			str += "}" + consts.LN
+			str += `pattern := strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(string(element))` + consts.LN
+			str += fmt.Sprintf(
+				`return %s.Where(fmt.Sprintf("(%%s = ? OR %%s LIKE ? ESCAPE '!' OR %%s LIKE ? ESCAPE '!' OR %%s LIKE ? ESCAPE '!')", column, column, column, column),`,
+				GORM_REQUEST_NAME,
+			) + consts.LN
+			str += `"["+string(element)+"]", "["+pattern+",%", "%,"+pattern+",%", "%,"+pattern+"]")`
 			return str, []*model.GoPkg{
 				consts.CommonPkgs["fmt"],
+				consts.CommonPkgs["json"],
 				consts.CommonPkgs["strings"],
-				consts.CommonPkgs["reflect"],
+				builder.DomainBuilder.GetRepositoryPackage(),
 			}
 		},
 	}
@@ -309,7 +347,7 @@ func (builder *GormDomainRepositoryBuilder) Build(ctx context.Context) error {
 		Name: GetGormDomainRepositoryName(ctx, builder.DomainBuilder.Definition),
 		Elements: []interface{}{
 			byOperatorToGormOperator,
-			valueToGormValue,
+			whereToGorm,
 			gormDomainRepo,
 		},
 		Pkg: builder.DomainBuilder.GetGormAdapterPackage(),
@@ -18,7 +18,7 @@ const (
 	GORM_METHOD_CONTEXT_NAME  = "methodCtx"
 	GORM_REQUEST_NAME         = "request"
 	OPERATOR_TO_GORM_OPERATOR = "RepositoryOperatorToGormOperator"
-	VALUE_TO_GORM_VALUE       = "ValueToGormValue"
+	WHERE_TO_GORM             = "WhereToGorm"
 )
 
 func ModelToGormModel(ctx context.Context, on *coredomaindefinition.Model) string {
@@ -51,6 +51,9 @@ type GormRepositoryBuilder struct {
 
 	GormModelToModel   []func() string
 	GormModelsToModels []func() string
+
+	// packages of the converters of external types used by the conversions
+	ConversionPkgs []*model.GoPkg
 }
 
 var _ Builder = (*GormRepositoryBuilder)(nil)
@@ -99,17 +102,28 @@ func NewGormRepositoryBuilder(
 	builder.GormModel.Elements = append(builder.GormModel.Elements, builder.Model)
 
 	modelFieldNames := []string{}
-	for _, f := range builder.DomainBuilder.DefaultModelFields {
+	for _, f := range builder.DomainBuilder.GetDefaultModelFields(ctx, definition.On) {
 		modelFieldNames = append(modelFieldNames, f.Name)
 		field, err := builder.DomainBuilder.FieldDefinitionToField(ctx, f)
 		if err != nil {
 			builder.Err = merror.Stack(err)
 			return builder
 		}
-		field.Tags = append(field.Tags, &model.Tag{
+		gormTag := &model.Tag{
 			Name:   "gorm",
 			Values: []string{"column:" + GetColumnNameFromName(ctx, field.Name)},
-		})
+		}
+		switch field.Name {
+		case consts.ID:
+			gormTag.Values = append(gormTag.Values, builder.DomainBuilder.GetIDGormTags(ctx, definition.On)...)
+		// timestamps are set by the usecases, not by gorm
+		case consts.CREATED_AT:
+			gormTag.Values = append(gormTag.Values, "autoCreateTime:false")
+		case consts.UPDATED_AT:
+			gormTag.Values = append(gormTag.Values, "autoUpdateTime:false")
+		}
+		gormTag.Values = append(gormTag.Values, builder.DomainBuilder.GetDefaultGormTags(ctx, f)...)
+		field.Tags = append(field.Tags, gormTag)
 		builder.Model.Fields = append(builder.Model.Fields, PrepareFieldFormGorm(ctx, field))
 
 		builder.ModelToGormModel = append(builder.ModelToGormModel, func() string {
@@ -210,17 +224,67 @@ func NewGormRepositoryBuilder(
 			builder.Err = merror.Stack(err)
 			return builder
 		}
-		f.Tags = append(f.Tags, &model.Tag{
+		gormTag := &model.Tag{
 			Name:   "gorm",
 			Values: []string{"column:" + GetColumnName(ctx, field)},
-		})
-		builder.Model.Fields = append(builder.Model.Fields, PrepareFieldFormGorm(ctx, f))
+		}
+		switch t := field.Type.(type) {
+		case *coredomaindefinition.Enum:
+			gormTag.Values = append(gormTag.Values, GetEnumCheckConstraint(ctx, GetColumnName(ctx, field), t))
+		case *coredomaindefinition.ValueObject:
+			// value object fields are stored in the model table, prefixed by the field column
+			gormTag.Values = []string{"embedded", "embeddedPrefix:" + GetColumnName(ctx, field) + "_"}
+		case coredomaindefinition.PrimitiveType:
+			switch t {
+			case coredomaindefinition.PrimitiveTypeDecimal:
+				gormTag.Values = append(gormTag.Values, "type:"+GetDecimalColumnType(ctx, field))
+			case coredomaindefinition.PrimitiveTypeMoney:
+				gormTag.Values = []string{"embedded", "embeddedPrefix:" + GetColumnName(ctx, field) + "_"}
+			case coredomaindefinition.PrimitiveTypeJSON:
+				gormTag.Values = append(gormTag.Values, "serializer:json")
+			case coredomaindefinition.PrimitiveTypeDate, coredomaindefinition.PrimitiveTypeTime:
+				gormTag.Values = append(gormTag.Values, GetCivilTimeGormTags(ctx, t)...)
+			}
+		case *coredomaindefinition.Array:
+			gormTag.Values = append(gormTag.Values, builder.DomainBuilder.GetArrayGormTags(ctx, field, t)...)
+		case *coredomaindefinition.Map:
+			gormTag.Values = append(gormTag.Values, "serializer:json")
+		}
+		gormTag.Values = append(gormTag.Values, builder.DomainBuilder.GetDefaultGormTags(ctx, field)...)
+		f.Tags = append(f.Tags, gormTag)
+		gormField := PrepareFieldFormGorm(ctx, f)
+
+		// the column of an external type with converters stores its representation
+		external, converted := field.Type.(*coredomaindefinition.ExternalType)
+		converted = converted && external.HasConverters()
+		if converted {
+			representation, err := builder.DomainBuilder.TypeDefinitionToType(ctx, external.GetRepresentation())
+			if err != nil {
+				builder.Err = merror.Stack(err)
+				return builder
+			}
+			if field.Optional {
+				representation = &model.PointerType{Type: representation}
+			}
+			gormField.Type = representation
+			builder.ConversionPkgs = append(builder.ConversionPkgs, GetExternalTypePkg(ctx, external))
+		}
+		builder.Model.Fields = append(builder.Model.Fields, gormField)
 
 		builder.ModelToGormModel = append(builder.ModelToGormModel, func() string {
-			return fmt.Sprintf(
-				"%s: %s.%s",
-				GetFieldName(ctx, field.Name), GORM_MODEL_METHOD_NAME, GetFieldName(ctx, field.Name),
-			) + "," + consts.LN
+			value := fmt.Sprintf("%s.%s", GORM_MODEL_METHOD_NAME, GetFieldName(ctx, field.Name))
+			if converted {
+				value = GetExternalTypeConversion(ctx, external, field.Optional, true, value)
+			}
+			return fmt.Sprintf("%s: %s", GetFieldName(ctx, field.Name), value) + "," + consts.LN
+		})
+
+		builder.GormModelToModel = append(builder.GormModelToModel, func() string {
+			value := fmt.Sprintf("%s.%s", GORM_MODEL_METHOD_NAME, GetFieldName(ctx, field.Name))
+			if converted {
+				value = GetExternalTypeConversion(ctx, external, field.Optional, false, value)
+			}
+			return fmt.Sprintf("%s: %s", GetFieldName(ctx, field.Name), value) + "," + consts.LN
 		})
 	}
 
@@ -278,7 +342,7 @@ func (builder *GormRepositoryBuilder) WithRelation(ctx context.Context, relation
 			return
 		}
 
-		var t model.Type = model.PrimitiveTypeString
+		t := builder.DomainBuilder.GetIDType(ctx, to)
 		dereference := ""
 		reference := ""
 		if optionnal {
@@ -541,7 +605,7 @@ func (builder *GormRepositoryBuilder) addDeleteMethod(ctx context.Context) {
 	methodName := GetRepositoryDeleteMethod(ctx, builder.Definition.On)
 
 	ctxName := GetMethodContextName(ctx, methodName)
-	method := GetRepositoryDeleteSignature(ctx, builder.Definition, builder.DomainBuilder.GetRepositoryPackage(), builder.DomainBuilder.GetModelPackage())
+	method := GetRepositoryDeleteSignature(ctx, builder.Definition, builder.DomainBuilder.GetRepositoryPackage(), builder.DomainBuilder.GetModelPackage(), builder.DomainBuilder.GetIDType(ctx, builder.Definition.On))
 	method.Content = func() (string, []*model.GoPkg) {
 		str := ""
 		pkg := []*model.GoPkg{
@@ -603,11 +667,11 @@ func (builder *GormRepositoryBuilder) addManyToManyMethods(ctx context.Context,
 			},
 			{
 				Name: builder.Definition.On.Name + "Id",
-				Type: model.PrimitiveTypeString,
+				Type: builder.DomainBuilder.GetIDType(ctx, builder.Definition.On),
 			},
 			{
 				Name: to.Name + "Id",
-				Type: model.PrimitiveTypeString,
+				Type: builder.DomainBuilder.GetIDType(ctx, to),
 			},
 			{
 				Name: "opts",
@@ -682,11 +746,11 @@ func (builder *GormRepositoryBuilder) addManyToManyMethods(ctx context.Context,
 			},
 			{
 				Name: builder.Definition.On.Name + "Id",
-				Type: model.PrimitiveTypeString,
+				Type: builder.DomainBuilder.GetIDType(ctx, builder.Definition.On),
 			},
 			{
 				Name: to.Name + "Id",
-				Type: model.PrimitiveTypeString,
+				Type: builder.DomainBuilder.GetIDType(ctx, to),
 			},
 			{
 				Name: "opts",
@@ -797,7 +861,7 @@ func (builder *GormRepositoryBuilder) addGormModelToModel(ctx context.Context) {
 				str += gormModelToModel()
 			}
 			str += "}"
-			return str, nil
+			return str, builder.ConversionPkgs
 		},
 	})
 }
@@ -842,7 +906,7 @@ func (builder *GormRepositoryBuilder) addModelToGormModel(ctx context.Context) {
 				str += modelToGormModel()
 			}
 			str += "}"
-			return str, nil
+			return str, builder.ConversionPkgs
 		},
 	})
 }
@@ -919,8 +983,8 @@ func (builder *GormRepositoryBuilder) getRequestModelWithDependencyTree(ctx cont
 	str += fmt.Sprintf("for _, where := range %s.%s {", GORM_METHOD_CONTEXT_NAME, REPOSITORY_BY) + consts.LN
 	str += fmt.Sprintf("if slices.Contains(%s.%s, where.Key){", builder.DomainBuilder.GetRepositoryPackage().Alias, GetRepositoryAllowedWhere(ctx, builder.Definition.On)) + consts.LN
 	str += fmt.Sprintf(
-		`%s = %s.Where(fmt.Sprintf("%s %s ?", %s.%s[where.Key], %s(where.Operator)), %s(where.Value))`,
-		GORM_REQUEST_NAME, GORM_REQUEST_NAME, "%s", "%s", builder.DomainBuilder.GetRepositoryPackage().Alias, GetRepositoryFieldToColumnName(ctx, builder.Definition), OPERATOR_TO_GORM_OPERATOR, VALUE_TO_GORM_VALUE,
+		`%s = %s(%s, %s.%s[where.Key], where)`,
+		GORM_REQUEST_NAME, WHERE_TO_GORM, GORM_REQUEST_NAME, builder.DomainBuilder.GetRepositoryPackage().Alias, GetRepositoryFieldToColumnName(ctx, builder.Definition),
 	) + consts.LN
 	str += "}" + consts.LN
 	str += "}" + consts.LN
@@ -928,7 +992,6 @@ func (builder *GormRepositoryBuilder) getRequestModelWithDependencyTree(ctx cont
 
 	return str, []*model.GoPkg{
 		consts.CommonPkgs["slices"],
-		consts.CommonPkgs["fmt"],
 		builder.DomainBuilder.GetRepositoryPackage(),
 	}
 }
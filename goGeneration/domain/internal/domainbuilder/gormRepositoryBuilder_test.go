@@ -0,0 +1,31 @@
+package domainbuilder
+
+import (
+	"testing"
+
+	"github.com/cleogithub/golem/coredomaindefinition"
+)
+
+func TestMapAndJSONGeneration(t *testing.T) {
+	shop := coredomaindefinition.NewModel("shop")
+	shop.Fields = []*coredomaindefinition.Field{
+		{Name: "labels", Type: &coredomaindefinition.Map{Key: coredomaindefinition.PrimitiveTypeString, Value: coredomaindefinition.PrimitiveTypeInt}},
+		{Name: "settings", Type: coredomaindefinition.PrimitiveTypeJSON},
+	}
+
+	files := generate(t, withCRUD(newTestDomain(shop), shop))
+
+	assertContains(t, files.goFile(t, "domain/model/shop"),
+		"Labels    map[string]int64 `json:\"labels\"`",
+		"Settings  json.RawMessage  `json:\"settings\"`",
+	)
+	// maps and raw JSON are stored in JSON columns whatever the dialect
+	assertContains(t, files.goFile(t, "adapter/repository/gormadapter/shop"),
+		"Labels    map[string]int64 `gorm:\"column:labels;serializer:json\"`",
+		"Settings  json.RawMessage  `gorm:\"column:settings;serializer:json\"`",
+	)
+	assertContains(t, files.jsFile(t, "entities"),
+		"\tlabels // Object\n",
+		"\tsettings // any\n",
+	)
+}
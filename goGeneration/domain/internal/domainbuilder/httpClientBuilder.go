@@ -23,6 +23,13 @@ const HTTP_CLIENT_ROUTE_TEMPLATE = `	body, err := json.Marshal(request)
 	if err != nil {
 		return nil, err
 	}
+	if status == http.StatusUnprocessableEntity {
+		validationErrors := {{ .UsecasePkg }}.{{ .ValidationErrors }}{}
+		if err = json.Unmarshal(resp, &validationErrors); err != nil {
+			return nil, err
+		}
+		return nil, validationErrors
+	}
 	if status != 200 {
 		return nil, httpclient.ErrUnexpectedStatus
 	}
@@ -34,10 +41,11 @@ const HTTP_CLIENT_ROUTE_TEMPLATE = `	body, err := json.Marshal(request)
 `
 
 type HttpClientRouteTemplate struct {
-	Route      string
-	ResultType string
-	UsecasePkg string
-	StructName string
+	Route            string
+	ResultType       string
+	UsecasePkg       string
+	StructName       string
+	ValidationErrors string
 }
 
 type HttpClientBuilder struct {
@@ -48,11 +56,17 @@ type HttpClientBuilder struct {
 
 	client *model.Struct
 
+	routeTemplate *template.Template
+
 	err error
 }
 
 func NewHttpClientBuilder(ctx context.Context, domainDefinition *coredomaindefinition.Domain, domain *model.Domain) *HttpClientBuilder {
+	routeTemplate, err := getTemplate(ctx, domainDefinition, HTTP_CLIENT_ROUTE_TEMPLATE_FILE, HTTP_CLIENT_ROUTE_TEMPLATE, HttpClientRouteTemplate{})
+
 	return &HttpClientBuilder{
+		routeTemplate:    routeTemplate,
+		err:              err,
 		domainDefinition: domainDefinition,
 		domain:           domain,
 
@@ -181,8 +195,12 @@ func (builder *HttpClientBuilder) addCRUDAction(ctx context.Context, action stri
 			},
 		},
 	}
-	route.Content = func() (content string, requiredPkg []*model.GoPkg) {
-		return builder.getRouteContent(ctx, GetUsecaseMethodName(ctx, method), response)
+	content, pkgs := builder.getRouteContent(ctx, GetUsecaseMethodName(ctx, method), response)
+	if builder.err != nil {
+		return
+	}
+	route.Content = func() (string, []*model.GoPkg) {
+		return content, pkgs
 	}
 
 	builder.client.Methods = append(builder.client.Methods, route)
@@ -225,25 +243,32 @@ func (builder *HttpClientBuilder) addRelationCRUDAction(ctx context.Context, act
 			},
 		},
 	}
-	route.Content = func() (content string, requiredPkg []*model.GoPkg) {
-		return builder.getRouteContent(ctx, GetUsecaseMethodName(ctx, method), GetUsecaseResponseName(ctx, method))
+	content, pkgs := builder.getRouteContent(ctx, GetUsecaseMethodName(ctx, method), GetUsecaseResponseName(ctx, method))
+	if builder.err != nil {
+		return
+	}
+	route.Content = func() (string, []*model.GoPkg) {
+		return content, pkgs
 	}
 
 	builder.client.Methods = append(builder.client.Methods, route)
 }
 
+// getRouteContent renders the route template, routes are rendered when they are added so that an error stops the build
 func (builder *HttpClientBuilder) getRouteContent(ctx context.Context, method string, response string) (string, []*model.GoPkg) {
 	tmpl := HttpClientRouteTemplate{
-		Route:      GetHttpRouteName(ctx, builder.domainDefinition, method),
-		ResultType: response,
-		UsecasePkg: builder.domain.Architecture.UsecasePkg.Alias,
-		StructName: builder.client.GetMethodName(),
+		Route:            GetHttpRouteName(ctx, builder.domainDefinition, method),
+		ResultType:       response,
+		UsecasePkg:       builder.domain.Architecture.UsecasePkg.Alias,
+		StructName:       builder.client.GetMethodName(),
+		ValidationErrors: VALIDATION_ERRORS_NAME,
 	}
 
 	buffer := bytes.NewBufferString("")
-	err := template.Must(template.New("route").Parse(HTTP_CLIENT_ROUTE_TEMPLATE)).Execute(buffer, tmpl)
+	err := builder.routeTemplate.Execute(buffer, tmpl)
 	if err != nil {
-		panic(err)
+		builder.err = merror.Stack(err)
+		return "", nil
 	}
 	return buffer.String(), []*model.GoPkg{
 		consts.CommonPkgs["json"],
@@ -289,8 +314,12 @@ func (builder *HttpClientBuilder) WithUsecase(ctx context.Context, definition *c
 			},
 		},
 	}
-	route.Content = func() (content string, requiredPkg []*model.GoPkg) {
-		return builder.getRouteContent(ctx, GetUsecaseMethodName(ctx, method), GetUsecaseResponseName(ctx, method))
+	content, pkgs := builder.getRouteContent(ctx, GetUsecaseMethodName(ctx, method), GetUsecaseResponseName(ctx, method))
+	if builder.err != nil {
+		return
+	}
+	route.Content = func() (string, []*model.GoPkg) {
+		return content, pkgs
 	}
 
 	builder.client.Methods = append(builder.client.Methods, route)
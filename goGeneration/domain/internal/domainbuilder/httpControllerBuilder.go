@@ -36,8 +36,9 @@ if err != nil {
 result, err := {{ .ControllerName }}.{{ .Usecases }}.{{ .UsecaseName }}(ctx, request)
 if err != nil {
 	if {{ .ControllerName }}.{{ .Validator }}.{{ .IsValidationErrorMethodName }}(ctx, err) {
+		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusUnprocessableEntity)
-		w.Write([]byte(err.Error()))
+		json.NewEncoder(w).Encode({{ .UsecasePkg }}.{{ .ToValidationErrors }}(err))
 		return
 	} else if errors.Is({{ .UsecasePkg }}.{{ .UsecaseErrUnauthorized }}, err) {
 		w.WriteHeader(http.StatusUnauthorized)
@@ -75,6 +76,7 @@ type RouteTemplate struct {
 	RepostiroyErrNotFound       string
 	Usecases                    string
 	IsValidationErrorMethodName string
+	ToValidationErrors          string
 	OptionalFieldExtraction     string
 	UsecaseErrNotAllowed        string
 	UsecaseErrUnauthorized      string
@@ -91,10 +93,15 @@ type HttpControllerBuilder struct {
 	controller *model.Struct
 
 	routeRegistration string
+	routeTemplate     *template.Template
 }
 
 func NewHttpControllerBuilder(ctx context.Context, domainDefinition *coredomaindefinition.Domain, domain *model.Domain) *HttpControllerBuilder {
+	routeTemplate, err := getTemplate(ctx, domainDefinition, ROUTE_TEMPLATE_FILE, ROUTE_TMPL, RouteTemplate{})
+
 	return &HttpControllerBuilder{
+		EmptyBuilder:     EmptyBuilder{err: err},
+		routeTemplate:    routeTemplate,
 		domainDefinition: domainDefinition,
 		domain:           domain,
 		controller: &model.Struct{
@@ -206,8 +213,12 @@ func (builder *HttpControllerBuilder) addCRUDAction(ctx context.Context, action
 	case LIST_ACTIVE:
 		method = GetCRUDMethodName(ctx, LIST, on)
 	}
-	route.Content = func() (content string, requiredPkg []*model.GoPkg) {
-		return builder.getRouteContent(ctx, GetUsecaseMethodName(ctx, method), GetUsecaseRequestName(ctx, method), "")
+	content, pkgs := builder.getRouteContent(ctx, GetUsecaseMethodName(ctx, method), GetUsecaseRequestName(ctx, method), "")
+	if builder.err != nil {
+		return
+	}
+	route.Content = func() (string, []*model.GoPkg) {
+		return content, pkgs
 	}
 
 	builder.controller.Methods = append(builder.controller.Methods, route)
@@ -224,8 +235,12 @@ func (builder *HttpControllerBuilder) addRelationCRUDAction(ctx context.Context,
 	}
 	method := GetCRUDRelationMethodName(ctx, action, from, to)
 	route := GetHttpRoute(ctx, method)
-	route.Content = func() (content string, requiredPkg []*model.GoPkg) {
-		return builder.getRouteContent(ctx, GetUsecaseMethodName(ctx, method), GetUsecaseRequestName(ctx, method), "")
+	content, pkgs := builder.getRouteContent(ctx, GetUsecaseMethodName(ctx, method), GetUsecaseRequestName(ctx, method), "")
+	if builder.err != nil {
+		return
+	}
+	route.Content = func() (string, []*model.GoPkg) {
+		return content, pkgs
 	}
 
 	builder.controller.Methods = append(builder.controller.Methods, route)
@@ -236,6 +251,7 @@ func (builder *HttpControllerBuilder) addRelationCRUDAction(ctx context.Context,
 	) + consts.LN
 }
 
+// getRouteContent renders the route template, routes are rendered when they are added so that an error stops the build
 func (builder *HttpControllerBuilder) getRouteContent(ctx context.Context, method string, request string, optionalFieldExtraction string) (string, []*model.GoPkg) {
 	tmpl := RouteTemplate{
 		ControllerName:              builder.controller.GetMethodName(),
@@ -247,6 +263,7 @@ func (builder *HttpControllerBuilder) getRouteContent(ctx context.Context, metho
 		RepostiroyErrNotFound:       REPOSITORY_ERROR_NOT_FOUND.Name,
 		Usecases:                    GetDomainUsecaseName(ctx, builder.domainDefinition.Name),
 		IsValidationErrorMethodName: VALIDATOR_IS_VALIDATION_ERROR_METHOD_NAME,
+		ToValidationErrors:          TO_VALIDATION_ERRORS_NAME,
 		OptionalFieldExtraction:     optionalFieldExtraction,
 		UsecaseErrUnauthorized:      ERR_UNAUTHORIZED_NAME,
 		UsecaseErrNotAllowed:        ERR_NOT_ALLOWED_NAME,
@@ -255,9 +272,10 @@ func (builder *HttpControllerBuilder) getRouteContent(ctx context.Context, metho
 	}
 
 	buffer := bytes.NewBufferString("")
-	err := template.Must(template.New("route").Parse(ROUTE_TMPL)).Execute(buffer, tmpl)
+	err := builder.routeTemplate.Execute(buffer, tmpl)
 	if err != nil {
-		panic(err)
+		builder.err = merror.Stack(err)
+		return "", nil
 	}
 	return buffer.String(), []*model.GoPkg{
 		builder.domain.Architecture.RepositoryPkg,
@@ -278,39 +296,51 @@ func (builder *HttpControllerBuilder) WithUsecase(ctx context.Context, definitio
 
 	method := GetUsecaseMethodName(ctx, definition.Name)
 	route := GetHttpRoute(ctx, method)
-	route.Content = func() (content string, requiredPkg []*model.GoPkg) {
-		fileIdx := 0
-		optionalContent := ""
-		for _, arg := range definition.Args {
-			if arg.Type == coredomaindefinition.PrimitiveTypeFile {
-				str := fmt.Sprintf(`file%d, _, err := r.FormFile("%s")`, fileIdx, arg.Name) + consts.LN
-				str += "if err != nil {" + consts.LN
-				str += "w.WriteHeader(http.StatusBadRequest)" + consts.LN
-				str += "return" + consts.LN
-				str += "}" + consts.LN
-				str += fmt.Sprintf(`defer file%d.Close()`, fileIdx) + consts.LN
-
-				str += fmt.Sprintf(`fileBytes%d, err := io.ReadAll(file%d)`, fileIdx, fileIdx) + consts.LN
-				str += "if err != nil {" + consts.LN
-				str += "w.WriteHeader(http.StatusBadRequest)" + consts.LN
-				str += "return" + consts.LN
-				str += "}" + consts.LN
-
-				str += fmt.Sprintf("request.%s = fileBytes%d", GetFieldName(ctx, arg.Name), fileIdx) + consts.LN
-
-				optionalContent += str
-				fileIdx++
-				pkgs = append(pkgs, consts.CommonPkgs["io"])
-			}
-		}
-
-		if optionalContent != "" {
-			optionalContent = "r.ParseMultipartForm(10 << 20)" + consts.LN + consts.LN + optionalContent
+	fileIdx := 0
+	optionalContent := ""
+	// the multipart form holds every file at its largest size in memory
+	maxMemory := int64(0)
+	for _, arg := range definition.Args {
+		if arg.Type == coredomaindefinition.PrimitiveTypeFile {
+			maxSize := GetFileMaxSize(ctx, arg.ToField())
+			maxMemory += maxSize
+
+			str := fmt.Sprintf(`file%d, header%d, err := r.FormFile("%s")`, fileIdx, fileIdx, arg.Name) + consts.LN
+			str += "if err != nil {" + consts.LN
+			str += "w.WriteHeader(http.StatusBadRequest)" + consts.LN
+			str += "return" + consts.LN
+			str += "}" + consts.LN
+			str += fmt.Sprintf(`defer file%d.Close()`, fileIdx) + consts.LN
+			str += fmt.Sprintf("if header%d.Size > %d {", fileIdx, maxSize) + consts.LN
+			str += "w.WriteHeader(http.StatusRequestEntityTooLarge)" + consts.LN
+			str += "return" + consts.LN
+			str += "}" + consts.LN
+
+			str += fmt.Sprintf(`fileBytes%d, err := io.ReadAll(file%d)`, fileIdx, fileIdx) + consts.LN
+			str += "if err != nil {" + consts.LN
+			str += "w.WriteHeader(http.StatusBadRequest)" + consts.LN
+			str += "return" + consts.LN
+			str += "}" + consts.LN
+
+			str += fmt.Sprintf("request.%s = fileBytes%d", GetFieldName(ctx, arg.Name), fileIdx) + consts.LN
+
+			optionalContent += str
+			fileIdx++
+			pkgs = append(pkgs, consts.CommonPkgs["io"])
 		}
+	}
 
-		str, pkgsContent := builder.getRouteContent(ctx, GetUsecaseMethodName(ctx, method), GetUsecaseRequestName(ctx, method), optionalContent)
+	if optionalContent != "" {
+		optionalContent = fmt.Sprintf("r.ParseMultipartForm(%d)", maxMemory) + consts.LN + consts.LN + optionalContent
+	}
 
-		return str, append(pkgs, pkgsContent...)
+	content, pkgsContent := builder.getRouteContent(ctx, GetUsecaseMethodName(ctx, method), GetUsecaseRequestName(ctx, method), optionalContent)
+	if builder.err != nil {
+		return
+	}
+	pkgs = append(pkgs, pkgsContent...)
+	route.Content = func() (string, []*model.GoPkg) {
+		return content, pkgs
 	}
 
 	builder.controller.Methods = append(builder.controller.Methods, route)
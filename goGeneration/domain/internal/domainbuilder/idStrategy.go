@@ -0,0 +1,160 @@
+package domainbuilder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cleogithub/golem/coredomaindefinition"
+	"github.com/cleogithub/golem/goGeneration/domain/consts"
+	"github.com/cleogithub/golem/goGeneration/domain/model"
+)
+
+const (
+	NEW_ULID_NAME      = "NewULID"
+	ULID_ENCODING_NAME = "ULID_ENCODING"
+	// Crockford's base32, without I, L, O and U
+	ULID_ENCODING = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+)
+
+// AUTOINCREMENT_ID_TYPE is the go type of autoincrement primary keys, int64 like the bigint columns holding them
+var AUTOINCREMENT_ID_TYPE = model.PrimitiveTypeInt
+
+// GetIDStrategy returns the primary key strategy of m
+func (domainBuilder *domainBuilder) GetIDStrategy(ctx context.Context, m *coredomaindefinition.Model) coredomaindefinition.IDStrategy {
+	return domainBuilder.Definition.GetIDStrategy(m)
+}
+
+// GetIDType returns the go type of the primary key of m, also used by the fields referencing m
+func (domainBuilder *domainBuilder) GetIDType(ctx context.Context, m *coredomaindefinition.Model) model.Type {
+	if domainBuilder.GetIDStrategy(ctx, m) == coredomaindefinition.IDStrategyAutoIncrement {
+		return AUTOINCREMENT_ID_TYPE
+	}
+	return model.PrimitiveTypeString
+}
+
+// GetIDJSType returns the javascript type of the primary key of m, numbers are exact up to Number.MAX_SAFE_INTEGER
+func (domainBuilder *domainBuilder) GetIDJSType(ctx context.Context, m *coredomaindefinition.Model) string {
+	if domainBuilder.GetIDStrategy(ctx, m) == coredomaindefinition.IDStrategyAutoIncrement {
+		return "number"
+	}
+	return "string"
+}
+
+// GetIDZeroValue returns the go literal of an unset primary key of m, the untyped 0 of an int64 for autoincrement keys
+func (domainBuilder *domainBuilder) GetIDZeroValue(ctx context.Context, m *coredomaindefinition.Model) string {
+	if domainBuilder.GetIDStrategy(ctx, m) == coredomaindefinition.IDStrategyAutoIncrement {
+		return "0"
+	}
+	return `""`
+}
+
+// GetIDValidationTags returns the validate tags checking the format of a primary key of m, required excluded
+func (domainBuilder *domainBuilder) GetIDValidationTags(ctx context.Context, m *coredomaindefinition.Model) []string {
+	switch domainBuilder.GetIDStrategy(ctx, m) {
+	case coredomaindefinition.IDStrategyULID:
+		return []string{"ulid"}
+	case coredomaindefinition.IDStrategyAutoIncrement:
+		return []string{"gt=0"}
+	}
+	return []string{"uuid"}
+}
+
+// GetIDGormTags returns the gorm tag values of the primary key column of m
+func (domainBuilder *domainBuilder) GetIDGormTags(ctx context.Context, m *coredomaindefinition.Model) []string {
+	if domainBuilder.GetIDStrategy(ctx, m) == coredomaindefinition.IDStrategyAutoIncrement {
+		return []string{"primaryKey", "autoIncrement"}
+	}
+	return []string{"primaryKey"}
+}
+
+// GetIDGeneration returns the statement declaring idVar with a new primary key of m.
+// It is empty for autoincrement keys, which are generated by the database.
+func (domainBuilder *domainBuilder) GetIDGeneration(ctx context.Context, m *coredomaindefinition.Model, idVar string) (string, []*model.GoPkg) {
+	switch domainBuilder.GetIDStrategy(ctx, m) {
+	case coredomaindefinition.IDStrategyUUIDv7:
+		return fmt.Sprintf("%s := %s.Must(%s.NewV7()).String()", idVar, consts.CommonPkgs["uuid"].Alias, consts.CommonPkgs["uuid"].Alias) + consts.LN,
+			[]*model.GoPkg{consts.CommonPkgs["uuid"]}
+	case coredomaindefinition.IDStrategyULID:
+		return fmt.Sprintf("%s := %s.%s()", idVar, domainBuilder.GetModelPackage().Alias, NEW_ULID_NAME) + consts.LN,
+			[]*model.GoPkg{domainBuilder.GetModelPackage()}
+	case coredomaindefinition.IDStrategyAutoIncrement:
+		return "", nil
+	}
+	return fmt.Sprintf("%s := %s.NewString()", idVar, consts.CommonPkgs["uuid"].Alias) + consts.LN,
+		[]*model.GoPkg{consts.CommonPkgs["uuid"]}
+}
+
+// GetDefaultModelFields returns the default fields of m, the id typed after the primary key strategy
+func (domainBuilder *domainBuilder) GetDefaultModelFields(ctx context.Context, m *coredomaindefinition.Model) []*coredomaindefinition.Field {
+	fields := []*coredomaindefinition.Field{}
+	for _, f := range domainBuilder.DefaultModelFields {
+		if GetFieldName(ctx, f.Name) == consts.ID && domainBuilder.GetIDStrategy(ctx, m) == coredomaindefinition.IDStrategyAutoIncrement {
+			// int fields are generated as int64, see AUTOINCREMENT_ID_TYPE
+			id := *f
+			id.Type = coredomaindefinition.PrimitiveTypeInt
+			f = &id
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// NEW_ULID generates a ULID with the standard library: 48 bits of unix milliseconds followed by 80 random bits
+var NEW_ULID = &model.Function{
+	Name:    NEW_ULID_NAME,
+	Results: []*model.Param{{Type: model.PrimitiveTypeString}},
+	Content: func() (string, []*model.GoPkg) {
+		str := "id := make([]byte, 16)" + consts.LN
+		str += "ms := uint64(time.Now().UnixMilli())" + consts.LN
+		str += "for i := 5; i >= 0; i-- {" + consts.LN
+		str += "id[i] = byte(ms)" + consts.LN
+		str += "ms >>= 8" + consts.LN
+		str += "}" + consts.LN
+		str += "if _, err := rand.Read(id[6:]); err != nil {" + consts.LN
+		str += "panic(err)" + consts.LN
+		str += "}" + consts.LN
+		str += consts.LN
+		str += "// 26 characters of 5 bits, the first one only holds the 3 leftover bits" + consts.LN
+		str += "value := new(big.Int).SetBytes(id)" + consts.LN
+		str += "mask := big.NewInt(31)" + consts.LN
+		str += "str := make([]byte, 26)" + consts.LN
+		str += "for i := len(str) - 1; i >= 0; i-- {" + consts.LN
+		str += fmt.Sprintf("str[i] = %s[new(big.Int).And(value, mask).Int64()]", ULID_ENCODING_NAME) + consts.LN
+		str += "value.Rsh(value, 5)" + consts.LN
+		str += "}" + consts.LN
+		str += "return string(str)"
+		return str, []*model.GoPkg{
+			consts.CommonPkgs["time"],
+			{Alias: "rand", ShortName: "rand", FullName: "crypto/rand"},
+			bigRat.Pkg,
+		}
+	},
+}
+
+// addULID adds the ULID generator to the model package when a model uses the ulid strategy
+func (domainBuilder *domainBuilder) addULID(ctx context.Context) {
+	if domainBuilder.err != nil {
+		return
+	}
+
+	for _, m := range domainBuilder.Definition.Models {
+		if domainBuilder.GetIDStrategy(ctx, m) != coredomaindefinition.IDStrategyULID {
+			continue
+		}
+
+		domainBuilder.Domain.Files = append(domainBuilder.Domain.Files, &model.File{
+			Name: "ulid",
+			Pkg:  domainBuilder.GetModelPackage(),
+			Elements: []interface{}{
+				&model.Var{
+					Name:    ULID_ENCODING_NAME,
+					Type:    model.PrimitiveTypeString,
+					Value:   ULID_ENCODING,
+					IsConst: true,
+				},
+				NEW_ULID,
+			},
+		})
+		return
+	}
+}
@@ -0,0 +1,37 @@
+package domainbuilder
+
+import (
+	"testing"
+
+	"github.com/cleogithub/golem/coredomaindefinition"
+)
+
+func TestIDStrategyGeneration(t *testing.T) {
+	shop := coredomaindefinition.NewModel("shop")
+	shop.IDStrategy = coredomaindefinition.IDStrategyAutoIncrement
+	user := coredomaindefinition.NewModel("user")
+	user.IDStrategy = coredomaindefinition.IDStrategyULID
+	definition := withCRUD(withCRUD(newTestDomain(shop, user), shop), user)
+
+	files := generate(t, definition)
+
+	// autoincrement keys are int64 set by the database
+	assertContains(t, files.goFile(t, "adapter/repository/gormadapter/shop"),
+		"Id        int64           `gorm:\"column:id;primaryKey;autoIncrement\"`",
+	)
+	assertContains(t, files.goFile(t, "adapter/repository/gormadapter/shopRepository"),
+		"func (repo ShopRepository) DeleteShop(ctx context.Context, id int64, opts ...repository.DeleteShopOpt) error {",
+	)
+	assertContains(t, files.goFile(t, "domain/model/shop"), "Id        int64     `json:\"id\"`")
+	assertContains(t, files.goFile(t, "domain/usecase/structs"),
+		"Id int64 `json:\"id\" validate:\"required,gt=0\"`",
+		"Id string `json:\"id\" validate:\"required,ulid\"`",
+	)
+	assertContains(t, files.jsFile(t, "entities"), "export class Shop {\n\tid // number\n")
+
+	// ulid keys are generated by the create usecase
+	assertContains(t, files.goFile(t, "domain/model/ulid"), "func NewULID() string {")
+	crud := files.goFile(t, "domain/usecase/shopUsecaseCRUD")
+	assertContains(t, goFunction(t, crud, "func (crud *ShopUsecaseCRUD) CreateUser("), "id := model.NewULID()")
+	assertNotContains(t, goFunction(t, crud, "func (crud *ShopUsecaseCRUD) CreateShop("), "NewULID", "uuid.New")
+}
@@ -9,6 +9,29 @@ import (
 	"github.com/cleogithub/golem/goGeneration/domain/consts"
 )
 
+// JS_VALIDATION_ERRORS is the rejection of the services on a validation failure, built from the ValidationErrors payload
+const JS_VALIDATION_ERRORS = `export class ValidationErrors extends Error {
+	errors
+
+	constructor(errors) {
+		super(errors.map(error => (error.field + ' ' + error.message).trim()).join(', '))
+		this.name = 'ValidationErrors'
+		this.errors = errors
+	}
+
+	// fields maps each invalid field to its first message, e.g. to set the errors of a form
+	get fields() {
+		const fields = {}
+		for (const error of this.errors) {
+			if (!(error.field in fields)) { fields[error.field] = error.message }
+		}
+		return fields
+	}
+
+	static from(data) { return new ValidationErrors(Array.isArray(data) ? data : []) }
+}
+`
+
 type JSBuilder struct {
 	EmptyBuilder
 
@@ -70,6 +93,44 @@ func (builder *JSBuilder) Build(ctx context.Context) error {
 		builder.domainBuilder.Domain.JSFiles = map[string]string{}
 	}
 	builder.domainBuilder.Domain.JSFiles["utils"] = content
+	builder.domainBuilder.Domain.JSFiles["validationErrors"] = JS_VALIDATION_ERRORS
+
+	if len(builder.domainDefinition.ValueObjects) > 0 {
+		builder.domainBuilder.Domain.JSFiles["entities"] += builder.getValueObjects(ctx)
+	}
+
+	if len(builder.domainDefinition.Enums) > 0 {
+		builder.domainBuilder.Domain.JSFiles["enums"] = builder.getEnums(ctx)
+	}
 
 	return nil
 }
+
+// getValueObjects returns a class for each value object, hydrated like model classes
+func (builder *JSBuilder) getValueObjects(ctx context.Context) string {
+	content := ""
+	for _, valueObject := range builder.domainDefinition.ValueObjects {
+		names := []string{}
+		fields := map[string]string{}
+		for _, f := range valueObject.Fields {
+			names = append(names, f.Name)
+			fields[f.Name], _ = JSHydration(ctx, f.Type, HYDRATOR_PARAM_NAME+"."+f.Name)
+		}
+		content += JSGetClassFromOrderedTransformationFields(GetValueObjectName(ctx, valueObject), names, fields) + consts.LN
+	}
+	return content
+}
+
+// getEnums returns a frozen object of the allowed values for each enum
+func (builder *JSBuilder) getEnums(ctx context.Context) string {
+	content := ""
+	for _, enum := range builder.domainDefinition.Enums {
+		content += fmt.Sprintf("export const %s = Object.freeze({", GetEnumName(ctx, enum)) + consts.LN
+		for _, value := range enum.Values {
+			content += consts.TAB + fmt.Sprintf("%s: %s,", GetJSEnumValueName(ctx, value), JSString(value)) + consts.LN
+		}
+		content += "})" + consts.LN
+		content += consts.LN
+	}
+	return content
+}
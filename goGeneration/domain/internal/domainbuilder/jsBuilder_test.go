@@ -0,0 +1,48 @@
+package domainbuilder
+
+import (
+	"testing"
+
+	"github.com/cleogithub/golem/coredomaindefinition"
+)
+
+func TestValueObjectGeneration(t *testing.T) {
+	address := &coredomaindefinition.ValueObject{
+		Name: "address",
+		Fields: []*coredomaindefinition.Field{
+			{Name: "street", Type: coredomaindefinition.PrimitiveTypeString},
+			{Name: "city", Type: coredomaindefinition.PrimitiveTypeString},
+		},
+	}
+	shop := coredomaindefinition.NewModel("shop")
+	shop.Fields = []*coredomaindefinition.Field{
+		{Name: "home", Type: address},
+		{Name: "work", Type: address, Optional: true},
+	}
+	definition := withCRUD(newTestDomain(shop), shop)
+	definition.ValueObjects = []*coredomaindefinition.ValueObject{address}
+
+	files := generate(t, definition)
+
+	assertContains(t, files.goFile(t, "domain/model/address"),
+		"type Address struct {\n\tStreet string `json:\"street\"`\n\tCity   string `json:\"city\"`\n}",
+	)
+	assertContains(t, files.goFile(t, "domain/model/shop"),
+		"Home      Address   `json:\"home\"`",
+		"Work      *Address  `json:\"work,omitempty\"`",
+	)
+	// the fields of a value object are columns of the model table
+	assertContains(t, files.goFile(t, "adapter/repository/gormadapter/shop"),
+		"Home      model.Address   `gorm:\"embedded;embeddedPrefix:home_\"`",
+		"Work      *model.Address  `gorm:\"embedded;embeddedPrefix:work_\"`",
+	)
+
+	// the javascript class keeps the order of the definition
+	entities := files.jsFile(t, "entities")
+	assertContains(t, entities,
+		"export class Address {\n\tstreet\n\tcity\n",
+		"constructor(street,city) {",
+		"this.home = Address.from(data.home)",
+		"this.work = Address.from(data.work)",
+	)
+}
@@ -44,7 +44,7 @@ func (builder *JSClassBuilder) addModelFields(ctx context.Context) {
 		return
 	}
 
-	for _, f := range builder.domainBuilder.DefaultModelFields {
+	for _, f := range builder.domainBuilder.GetDefaultModelFields(ctx, builder.definition) {
 		builder.addModelField(ctx, f)
 	}
 	if builder.definition.Archivable {
@@ -71,16 +71,23 @@ func (builder *JSClassBuilder) addModelField(ctx context.Context, f *coredomaind
 		return
 	}
 
-	t, err := builder.domainBuilder.TypeDefinitionToType(ctx, f.Type)
-	if err != nil {
+	if _, err := builder.domainBuilder.TypeDefinitionToType(ctx, f.Type); err != nil {
 		builder.err = err
 		return
 	}
 
-	builder.fields += consts.TAB + fmt.Sprintf("%s // %s", f.Name, t.GetType()) + consts.LN
+	builder.fields += consts.TAB + fmt.Sprintf("%s // %s", f.Name, GetJSType(ctx, f.Type)) + consts.LN
+	// defaults are set by the create request classes and the server, not when an entity is hydrated
 	builder.constructorParams += fmt.Sprintf("%s,", f.Name)
 	builder.constructor += consts.TAB + consts.TAB + fmt.Sprintf("this.%s = %s", f.Name, f.Name) + consts.LN
-	builder.hydrator += consts.TAB + consts.TAB + fmt.Sprintf("if (%s.%s) this.%s = %s.%s;", HYDRATOR_PARAM_NAME, f.Name, f.Name, HYDRATOR_PARAM_NAME, f.Name) + consts.LN
+	value := HYDRATOR_PARAM_NAME + "." + f.Name
+	// false, 0 and empty strings are values
+	condition := value + " != null"
+	if _, ok := f.Type.(*coredomaindefinition.Array); ok && GetJSType(ctx, f.Type) != "string" {
+		condition = fmt.Sprintf("Array.isArray(%s)", value)
+	}
+	value, _ = JSHydration(ctx, f.Type, value)
+	builder.hydrator += consts.TAB + consts.TAB + fmt.Sprintf("if (%s) this.%s = %s;", condition, f.Name, value) + consts.LN
 }
 
 func (builder *JSClassBuilder) WithRelation(ctx context.Context, definition *coredomaindefinition.Relation) {
@@ -103,7 +110,7 @@ func (builder *JSClassBuilder) WithRelation(ctx context.Context, definition *cor
 	}
 
 	if IsRelationMultiple(ctx, builder.definition, definition) {
-		builder.fields += consts.TAB + fmt.Sprintf("%s // []%s", PluralizeName(ctx, to.Name), GetModelName(ctx, to)) + consts.LN
+		builder.fields += consts.TAB + fmt.Sprintf("%s // %s[]", PluralizeName(ctx, to.Name), GetModelName(ctx, to)) + consts.LN
 
 		builder.hydrator += consts.TAB + consts.TAB + fmt.Sprintf(
 			"if (%s.%s && Array.isArray(%s.%s)) {",
@@ -117,7 +124,7 @@ func (builder *JSClassBuilder) WithRelation(ctx context.Context, definition *cor
 	} else {
 		builder.fields += consts.TAB + fmt.Sprintf("%s // %s", to.Name, GetModelName(ctx, to)) + consts.LN
 		idRelationFieldName := stringtool.LowerFirstLetter(GetSingleRelationIdName(ctx, to))
-		builder.fields += consts.TAB + fmt.Sprintf("%s // string", idRelationFieldName) + consts.LN
+		builder.fields += consts.TAB + fmt.Sprintf("%s // %s", idRelationFieldName, builder.domainBuilder.GetIDJSType(ctx, to)) + consts.LN
 		optional, err := IsRelationOptionnal(ctx, builder.definition, definition)
 		if err != nil {
 			builder.err = err
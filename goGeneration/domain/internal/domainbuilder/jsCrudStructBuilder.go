@@ -27,9 +27,10 @@ type JSCRUDStructBuilder struct {
 	ListRequestFields  map[string]string
 	ListResponseFields map[string]string
 
-	CreateImports        []string
-	CreateRequestFields  map[string]string
-	CreateResponseFields map[string]string
+	CreateImports         []string
+	CreateRequestFields   map[string]string
+	CreateRequestDefaults map[string]string
+	CreateResponseFields  map[string]string
 
 	UpdateImports        []string
 	UpdateRequestFields  map[string]string
@@ -49,7 +50,7 @@ func NewJSCRUDStructBuilder(ctx context.Context, domainBuilder *domainBuilder, d
 
 	if definition.Get.Active {
 		builder.GetRequestFields = map[string]string{
-			"id": "id",
+			"id": HYDRATOR_PARAM_NAME + ".id",
 		}
 
 		builder.GetResponseFields = map[string]string{}
@@ -76,7 +77,13 @@ func NewJSCRUDStructBuilder(ctx context.Context, domainBuilder *domainBuilder, d
 
 	if definition.Create.Active {
 		builder.CreateRequestFields = map[string]string{}
-		builder.CreateRequestFields = builder.addModelFields(ctx, builder.CreateRequestFields)
+		builder.CreateRequestFields, builder.CreateImports = builder.addModelFields(ctx, builder.CreateRequestFields, builder.CreateImports)
+		builder.CreateRequestDefaults = map[string]string{}
+		for _, field := range builder.definition.On.Fields {
+			if value := GetJSDefault(ctx, field); value != "" {
+				builder.CreateRequestDefaults[field.Name] = value
+			}
+		}
 
 		builder.CreateResponseFields = map[string]string{}
 		builder.CreateResponseFields[builder.definition.On.Name] = fmt.Sprintf("%s.from(%s)", GetModelName(ctx, builder.definition.On), HYDRATOR_PARAM_NAME)
@@ -87,19 +94,19 @@ func NewJSCRUDStructBuilder(ctx context.Context, domainBuilder *domainBuilder, d
 
 	if definition.Update.Active {
 		builder.UpdateRequestFields = map[string]string{}
-		builder.UpdateRequestFields[stringtool.LowerFirstLetter(consts.ID)] = stringtool.LowerFirstLetter(consts.ID)
-		builder.UpdateRequestFields = builder.addModelFields(ctx, builder.UpdateRequestFields)
+		builder.UpdateRequestFields[stringtool.LowerFirstLetter(consts.ID)] = HYDRATOR_PARAM_NAME + "." + stringtool.LowerFirstLetter(consts.ID)
+		builder.UpdateRequestFields, builder.UpdateImports = builder.addModelFields(ctx, builder.UpdateRequestFields, builder.UpdateImports)
 
 		builder.UpdateResponseFields = map[string]string{}
 		builder.UpdateResponseFields[builder.definition.On.Name] = fmt.Sprintf("%s.from(%s)", GetModelName(ctx, builder.definition.On), HYDRATOR_PARAM_NAME)
-		if !slices.Contains(builder.CreateImports, GetModelName(ctx, builder.definition.On)) {
-			builder.CreateImports = append(builder.CreateImports, GetModelName(ctx, builder.definition.On))
+		if !slices.Contains(builder.UpdateImports, GetModelName(ctx, builder.definition.On)) {
+			builder.UpdateImports = append(builder.UpdateImports, GetModelName(ctx, builder.definition.On))
 		}
 	}
 
 	if definition.Delete.Active {
 		builder.GetRequestFields = map[string]string{
-			"id": "id",
+			"id": HYDRATOR_PARAM_NAME + ".id",
 		}
 	}
 
@@ -252,7 +259,7 @@ func (builder *JSCRUDStructBuilder) WithRelation(ctx context.Context, definition
 	}
 }
 
-func (builder *JSCRUDStructBuilder) addFile(ctx context.Context, action string, imports []string, requestFields map[string]string, responseFields map[string]string) {
+func (builder *JSCRUDStructBuilder) addFile(ctx context.Context, action string, imports []string, requestFields map[string]string, requestDefaults map[string]string, responseFields map[string]string) {
 	if builder.err != nil {
 		return
 	}
@@ -271,7 +278,7 @@ func (builder *JSCRUDStructBuilder) addFile(ctx context.Context, action string,
 		str += consts.LN
 	}
 
-	str += JSGetClassFromTransformationFields(action, requestFields)
+	str += JSGetClassFromTransformationFieldsWithDefaults(action, requestFields, requestDefaults)
 	str += consts.LN
 
 	str += JSGetClassFromTransformationFields(action, responseFields)
@@ -280,20 +287,24 @@ func (builder *JSCRUDStructBuilder) addFile(ctx context.Context, action string,
 	builder.domainBuilder.Domain.JSFiles[action] = str
 }
 
-func (builder *JSCRUDStructBuilder) addModelFields(ctx context.Context, fields map[string]string) map[string]string {
+func (builder *JSCRUDStructBuilder) addModelFields(ctx context.Context, fields map[string]string, imports []string) (map[string]string, []string) {
 	if builder.err != nil {
-		return fields
+		return fields, imports
 	}
 
 	for _, field := range builder.definition.On.Fields {
-		fields[field.Name] = field.Name
+		expression, class := JSHydration(ctx, field.Type, HYDRATOR_PARAM_NAME+"."+field.Name)
+		fields[field.Name] = expression
+		if class != "" && !slices.Contains(imports, class) {
+			imports = append(imports, class)
+		}
 	}
 
 	if builder.definition.On.Activable {
-		fields[stringtool.LowerFirstLetter(ACTIVE_FIELD_NAME)] = stringtool.LowerFirstLetter(ACTIVE_FIELD_NAME)
+		fields[stringtool.LowerFirstLetter(ACTIVE_FIELD_NAME)] = HYDRATOR_PARAM_NAME + "." + stringtool.LowerFirstLetter(ACTIVE_FIELD_NAME)
 	}
 
-	return fields
+	return fields, imports
 }
 
 func (builder *JSCRUDStructBuilder) Build(ctx context.Context) error {
@@ -302,31 +313,31 @@ func (builder *JSCRUDStructBuilder) Build(ctx context.Context) error {
 	}
 
 	if builder.definition.Get.Active {
-		builder.addFile(ctx, GetCRUDMethodName(ctx, GET, builder.definition.On), builder.GetImports, builder.GetRequestFields, builder.GetResponseFields)
+		builder.addFile(ctx, GetCRUDMethodName(ctx, GET, builder.definition.On), builder.GetImports, builder.GetRequestFields, nil, builder.GetResponseFields)
 	}
 
 	if builder.definition.GetActive.Active {
-		builder.addFile(ctx, GetCRUDMethodName(ctx, GET_ACTIVE, builder.definition.On), builder.GetImports, builder.GetRequestFields, builder.GetResponseFields)
+		builder.addFile(ctx, GetCRUDMethodName(ctx, GET_ACTIVE, builder.definition.On), builder.GetImports, builder.GetRequestFields, nil, builder.GetResponseFields)
 	}
 
 	if builder.definition.List.Active {
-		builder.addFile(ctx, GetCRUDMethodName(ctx, LIST, builder.definition.On), builder.ListImports, builder.ListRequestFields, builder.ListResponseFields)
+		builder.addFile(ctx, GetCRUDMethodName(ctx, LIST, builder.definition.On), builder.ListImports, builder.ListRequestFields, nil, builder.ListResponseFields)
 	}
 
 	if builder.definition.ListActive.Active {
-		builder.addFile(ctx, GetCRUDMethodName(ctx, LIST_ACTIVE, builder.definition.On), builder.ListImports, builder.ListRequestFields, builder.ListResponseFields)
+		builder.addFile(ctx, GetCRUDMethodName(ctx, LIST_ACTIVE, builder.definition.On), builder.ListImports, builder.ListRequestFields, nil, builder.ListResponseFields)
 	}
 
 	if builder.definition.Create.Active {
-		builder.addFile(ctx, GetCRUDMethodName(ctx, CREATE, builder.definition.On), builder.CreateImports, builder.CreateRequestFields, builder.CreateResponseFields)
+		builder.addFile(ctx, GetCRUDMethodName(ctx, CREATE, builder.definition.On), builder.CreateImports, builder.CreateRequestFields, builder.CreateRequestDefaults, builder.CreateResponseFields)
 	}
 
 	if builder.definition.Update.Active {
-		builder.addFile(ctx, GetCRUDMethodName(ctx, UPDATE, builder.definition.On), builder.UpdateImports, builder.UpdateRequestFields, builder.UpdateResponseFields)
+		builder.addFile(ctx, GetCRUDMethodName(ctx, UPDATE, builder.definition.On), builder.UpdateImports, builder.UpdateRequestFields, nil, builder.UpdateResponseFields)
 	}
 
 	if builder.definition.Delete.Active {
-		builder.addFile(ctx, GetCRUDMethodName(ctx, DELETE, builder.definition.On), builder.DeleteImports, builder.DeleteRequestFields, builder.DeleteResponseFields)
+		builder.addFile(ctx, GetCRUDMethodName(ctx, DELETE, builder.definition.On), builder.DeleteImports, builder.DeleteRequestFields, nil, builder.DeleteResponseFields)
 	}
 
 	return nil
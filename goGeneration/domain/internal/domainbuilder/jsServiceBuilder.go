@@ -141,25 +141,15 @@ func (builder *JSServiceBuilder) WithUsecase(ctx context.Context, definition *co
 	}
 
 	imports := []string{}
+	responseNames := []string{}
 	responseFields := map[string]string{}
 	for _, field := range definition.Results {
-		if m, ok := field.Type.(*coredomaindefinition.Model); ok {
-			if !slices.Contains(imports, GetModelName(ctx, m)) {
-				imports = append(imports, GetModelName(ctx, m))
-			}
-			responseFields[field.Name] = fmt.Sprintf("%s.from(data)", GetModelName(ctx, m))
-		} else if a, ok := field.Type.(*coredomaindefinition.Array); ok {
-			if m, ok := a.Type.(*coredomaindefinition.Model); ok {
-				if !slices.Contains(imports, GetModelName(ctx, m)) {
-					imports = append(imports, GetModelName(ctx, m))
-				}
-				responseFields[field.Name] = fmt.Sprintf("data.map((el) => %s.from(el))", GetModelName(ctx, m))
-			} else {
-				responseFields[field.Name] = fmt.Sprintf("data.%s", field.Name)
-			}
-		} else {
-			responseFields[field.Name] = fmt.Sprintf("data.%s", field.Name)
+		responseNames = append(responseNames, field.Name)
+		expression, class := JSHydration(ctx, field.Type, HYDRATOR_PARAM_NAME+"."+field.Name)
+		if class != "" && !slices.Contains(imports, class) {
+			imports = append(imports, class)
 		}
+		responseFields[field.Name] = expression
 	}
 
 	content := ""
@@ -168,7 +158,7 @@ func (builder *JSServiceBuilder) WithUsecase(ctx context.Context, definition *co
 		content += consts.LN
 	}
 	content += JSGetClassFromSimpleFields(request, requestFields) + consts.LN
-	content += JSGetClassFromTransformationFields(GetUsecaseResponseName(ctx, method), responseFields)
+	content += JSGetClassFromOrderedTransformationFields(GetUsecaseResponseName(ctx, method), responseNames, responseFields)
 
 	if builder.domainBuilder.Domain.JSFiles == nil {
 		builder.domainBuilder.Domain.JSFiles = map[string]string{}
@@ -197,6 +187,10 @@ func (builder *JSServiceBuilder) addMethod(ctx context.Context, method string, r
 	builder.methods += consts.TAB + consts.TAB + consts.TAB + consts.TAB + fmt.Sprintf("resolve(%s.from(response.data))", GetUsecaseResponseName(ctx, method)) + consts.LN
 	builder.methods += consts.TAB + consts.TAB + consts.TAB + "})" + consts.LN
 	builder.methods += consts.TAB + consts.TAB + consts.TAB + ".catch(error => {" + consts.LN
+	builder.methods += consts.TAB + consts.TAB + consts.TAB + consts.TAB + "if (error.response && error.response.status === 422) {" + consts.LN
+	builder.methods += consts.TAB + consts.TAB + consts.TAB + consts.TAB + consts.TAB + "reject(ValidationErrors.from(error.response.data))" + consts.LN
+	builder.methods += consts.TAB + consts.TAB + consts.TAB + consts.TAB + consts.TAB + "return" + consts.LN
+	builder.methods += consts.TAB + consts.TAB + consts.TAB + consts.TAB + "}" + consts.LN
 	builder.methods += consts.TAB + consts.TAB + consts.TAB + consts.TAB + "reject(error)" + consts.LN
 	builder.methods += consts.TAB + consts.TAB + consts.TAB + "})" + consts.LN
 	builder.methods += consts.TAB + consts.TAB + "})" + consts.LN
@@ -209,7 +203,7 @@ func (builder *JSServiceBuilder) Build(ctx context.Context) error {
 		return builder.err
 	}
 
-	content := fmt.Sprintf("import {\n\t%s\n} from './';", strings.Join(builder.typesImports, ",\n\t")) + consts.LN
+	content := fmt.Sprintf("import {\n\t%s\n} from './';", strings.Join(append(builder.typesImports, "ValidationErrors"), ",\n\t")) + consts.LN
 	content += consts.LN
 
 	content += fmt.Sprintf("export class %sService {", stringtool.UpperFirstLetter(builder.domainBuilder.Definition.Name)) + consts.LN
@@ -1,10 +1,13 @@
 package domainbuilder
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/cleogithub/golem-common/pkg/stringtool"
+	"github.com/cleogithub/golem/coredomaindefinition"
 	"github.com/cleogithub/golem/goGeneration/domain/consts"
 	"github.com/cleogithub/golem/goGeneration/domain/model"
 )
@@ -89,6 +92,7 @@ func JSGetClassFromSimpleFields(name string, fields []string) string {
 	for _, field := range fields {
 		str += consts.TAB + consts.TAB + fmt.Sprintf("if ( data.%s ) { this.%s = data.%s }", field, field, field) + consts.LN
 	}
+	str += consts.TAB + consts.TAB + "return this" + consts.LN
 	str += consts.TAB + "}" + consts.LN
 	str += consts.LN
 
@@ -100,28 +104,55 @@ func JSGetClassFromSimpleFields(name string, fields []string) string {
 }
 
 func JSGetClassFromTransformationFields(name string, fields map[string]string) string {
-	str := fmt.Sprintf("export class %s {", name) + consts.LN
+	return JSGetClassFromTransformationFieldsWithDefaults(name, fields, nil)
+}
+
+// JSGetClassFromTransformationFieldsWithDefaults is JSGetClassFromTransformationFields with default values of the constructor parameters
+func JSGetClassFromTransformationFieldsWithDefaults(name string, fields map[string]string, defaults map[string]string) string {
+	// sort fields for a deterministic output
+	names := make([]string, 0, len(fields))
 	for field := range fields {
+		names = append(names, field)
+	}
+	sort.Strings(names)
+
+	return jsGetClass(name, names, fields, defaults)
+}
+
+// JSGetClassFromOrderedTransformationFields is JSGetClassFromTransformationFields with the fields in the order of names
+func JSGetClassFromOrderedTransformationFields(name string, names []string, fields map[string]string) string {
+	return jsGetClass(name, names, fields, nil)
+}
+
+func jsGetClass(name string, names []string, fields map[string]string, defaults map[string]string) string {
+	str := fmt.Sprintf("export class %s {", name) + consts.LN
+	for _, field := range names {
 		str += consts.TAB + field + consts.LN
 	}
 	str += consts.LN
 
 	str += consts.TAB + "constructor("
-	for field := range fields {
-		str += field + ","
+	for _, field := range names {
+		if value, ok := defaults[field]; ok {
+			str += fmt.Sprintf("%s = %s,", field, value)
+		} else {
+			str += field + ","
+		}
 	}
 	str = strings.TrimSuffix(str, ",")
 	str += ") {" + consts.LN
-	for field := range fields {
+	for _, field := range names {
 		str += consts.TAB + consts.TAB + fmt.Sprintf("this.%s = %s", field, field) + consts.LN
 	}
 	str += consts.TAB + "}" + consts.LN
 	str += consts.LN
 
 	str += consts.TAB + "hydrate(data) {" + consts.LN
-	for field, fieldTransformation := range fields {
-		str += consts.TAB + consts.TAB + fmt.Sprintf("if ( data.%s ) { this.%s = %s }", field, field, fieldTransformation) + consts.LN
+	for _, field := range names {
+		// false, 0 and empty strings replace the defaults
+		str += consts.TAB + consts.TAB + fmt.Sprintf("if ( data.%s != null ) { this.%s = %s }", field, field, fields[field]) + consts.LN
 	}
+	str += consts.TAB + consts.TAB + "return this" + consts.LN
 	str += consts.TAB + "}" + consts.LN
 	str += consts.LN
 
@@ -131,3 +162,70 @@ func JSGetClassFromTransformationFields(name string, fields map[string]string) s
 
 	return str
 }
+
+// JSString returns value as a single quoted javascript string
+func JSString(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\r", `\r`).Replace(value) + "'"
+}
+
+// JSHydration returns the expression hydrating value of type t: the class of a value object or a model, a Date of a datetime,
+// or of each element of an array of them. Other values, dates and times of day included, are kept as received.
+// class is the class to import, empty when none.
+func JSHydration(ctx context.Context, t coredomaindefinition.Type, value string) (expression string, class string) {
+	if array, ok := t.(*coredomaindefinition.Array); ok {
+		element, class := JSHydration(ctx, array.Type, "elem")
+		if element == "elem" {
+			return value, class
+		}
+		return fmt.Sprintf("%s.map((elem) => %s)", value, element), class
+	}
+
+	switch t := t.(type) {
+	case *coredomaindefinition.ValueObject:
+		class = GetValueObjectName(ctx, t)
+		return fmt.Sprintf("%s.%s(%s)", class, JS_FROM_METHOD_NAME, value), class
+	case *coredomaindefinition.Model:
+		class = GetModelName(ctx, t)
+		return fmt.Sprintf("%s.%s(%s)", class, JS_FROM_METHOD_NAME, value), class
+	}
+	if t == coredomaindefinition.PrimitiveTypeDateTime {
+		return fmt.Sprintf("new Date(%s)", value), ""
+	}
+	return value, ""
+}
+
+// GetJSType returns the javascript type of the JSON value of t, written in the class field comments
+func GetJSType(ctx context.Context, t coredomaindefinition.Type) string {
+	switch t := t.(type) {
+	case *coredomaindefinition.Array:
+		if t.Type == coredomaindefinition.PrimitiveTypeByte {
+			// encoding/json writes bytes as a base64 string
+			return "string"
+		}
+		return GetJSType(ctx, t.Type) + "[]"
+	case *coredomaindefinition.Map:
+		return "Object"
+	case *coredomaindefinition.Enum:
+		return GetEnumName(ctx, t)
+	case *coredomaindefinition.ValueObject:
+		return GetValueObjectName(ctx, t)
+	case *coredomaindefinition.Model:
+		return GetModelName(ctx, t)
+	case *coredomaindefinition.ExternalType:
+		return GetJSType(ctx, t.GetRepresentation())
+	}
+
+	switch t {
+	case coredomaindefinition.PrimitiveTypeInt, coredomaindefinition.PrimitiveTypeFloat:
+		return "number"
+	case coredomaindefinition.PrimitiveTypeBool:
+		return "boolean"
+	case coredomaindefinition.PrimitiveTypeDateTime:
+		return "Date"
+	case coredomaindefinition.PrimitiveTypeMoney:
+		return "Object"
+	case coredomaindefinition.PrimitiveTypeJSON:
+		return "any"
+	}
+	return "string"
+}
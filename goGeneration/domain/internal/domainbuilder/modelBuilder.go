@@ -144,7 +144,7 @@ func (builder *ModelBuilder) WithRelation(ctx context.Context, relationDefinitio
 		})
 		builder.Model.Fields = append(builder.Model.Fields, &model.Field{
 			Name: GetSingleRelationIdName(ctx, to),
-			Type: model.PrimitiveTypeString,
+			Type: builder.DomainBuilder.GetIDType(ctx, to),
 			Tags: []*model.Tag{{Name: "json", Values: []string{
 				stringtool.LowerFirstLetter(GetSingleRelationIdName(ctx, to)),
 			}}},
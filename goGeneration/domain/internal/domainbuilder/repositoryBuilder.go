@@ -81,7 +81,7 @@ func NewRepositoryBuilder(
 		Name:   GetRepositoryAllowedWhere(ctx, definition.On),
 		Values: []interface{}{},
 	}
-	for _, f := range builder.DomainBuilder.DefaultModelFields {
+	for _, f := range builder.DomainBuilder.GetDefaultModelFields(ctx, definition.On) {
 		builder.FieldToColumn.Values = append(builder.FieldToColumn.Values, model.MapValue{
 			Key:   GetFieldName(ctx, f.Name),
 			Value: GetColumnName(ctx, f),
@@ -330,6 +330,7 @@ func (builder *RepositoryBuilder) addDeleteMethod(ctx context.Context) {
 		builder.Definition,
 		builder.DomainBuilder.GetRepositoryPackage(),
 		builder.DomainBuilder.GetModelPackage(),
+		builder.DomainBuilder.GetIDType(ctx, builder.Definition.On),
 	))
 }
 
@@ -372,11 +373,11 @@ func (builder *RepositoryBuilder) addManyToManyMethods(ctx context.Context, rela
 			},
 			{
 				Name: builder.Definition.On.Name + "Id",
-				Type: model.PrimitiveTypeString,
+				Type: builder.DomainBuilder.GetIDType(ctx, builder.Definition.On),
 			},
 			{
 				Name: to.Name + "Id",
-				Type: model.PrimitiveTypeString,
+				Type: builder.DomainBuilder.GetIDType(ctx, to),
 			},
 			{
 				Name: "opts",
@@ -424,11 +425,11 @@ func (builder *RepositoryBuilder) addManyToManyMethods(ctx context.Context, rela
 			},
 			{
 				Name: builder.Definition.On.Name + "Id",
-				Type: model.PrimitiveTypeString,
+				Type: builder.DomainBuilder.GetIDType(ctx, builder.Definition.On),
 			},
 			{
 				Name: to.Name + "Id",
-				Type: model.PrimitiveTypeString,
+				Type: builder.DomainBuilder.GetIDType(ctx, to),
 			},
 			{
 				Name: "opts",
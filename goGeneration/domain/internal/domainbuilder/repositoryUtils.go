@@ -325,7 +325,7 @@ func GetRepositoryUpdateSignature(ctx context.Context, repository *coredomaindef
 	}
 }
 
-func GetRepositoryDeleteSignature(ctx context.Context, repository *coredomaindefinition.Repository, repositoryPkg *model.GoPkg, modelPkg *model.GoPkg) *model.Function {
+func GetRepositoryDeleteSignature(ctx context.Context, repository *coredomaindefinition.Repository, repositoryPkg *model.GoPkg, modelPkg *model.GoPkg, idType model.Type) *model.Function {
 	methodName := GetRepositoryDeleteMethod(ctx, repository.On)
 	return &model.Function{
 		Name: methodName,
@@ -341,7 +341,7 @@ func GetRepositoryDeleteSignature(ctx context.Context, repository *coredomaindef
 			},
 			{
 				Name: "id",
-				Type: model.PrimitiveTypeString,
+				Type: idType,
 			},
 			{
 				Name: REPOSIOTY_METHOD_CONTEXT_OPTS_NAME,
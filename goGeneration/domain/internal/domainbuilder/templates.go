@@ -0,0 +1,118 @@
+package domainbuilder
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/cleogithub/golem-common/pkg/merror"
+	"github.com/cleogithub/golem/coredomaindefinition"
+)
+
+// Template files looked up in the TemplatesPath of the domain configuration to override built-in templates
+const (
+	ROUTE_TEMPLATE_FILE             = "route.tmpl"           // executed with RouteTemplate
+	HTTP_CLIENT_ROUTE_TEMPLATE_FILE = "httpClientRoute.tmpl" // executed with HttpClientRouteTemplate
+)
+
+// getTemplate returns the override of file from the templates folder if it exists, the built-in content otherwise.
+// The template is checked against the fields of data so an unknown field fails the generation instead of the execution.
+func getTemplate(ctx context.Context, definition *coredomaindefinition.Domain, file string, content string, data interface{}) (*template.Template, error) {
+	name := file
+	if definition.Configuration != nil && definition.Configuration.TemplatesPath != "" {
+		path := filepath.Join(definition.Configuration.TemplatesPath, file)
+		override, err := os.ReadFile(path)
+		if err == nil {
+			name, content = path, string(override)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return nil, merror.Stack(err)
+		}
+	}
+
+	tmpl, err := template.New(name).Parse(content)
+	if err != nil {
+		return nil, merror.Stack(NewErrInvalidTemplate(name, err))
+	}
+
+	fields := map[string]bool{}
+	dataType := reflect.TypeOf(data)
+	for i := 0; i < dataType.NumField(); i++ {
+		if dataType.Field(i).IsExported() {
+			fields[dataType.Field(i).Name] = true
+		}
+	}
+	for _, t := range tmpl.Templates() {
+		if t.Tree == nil {
+			continue
+		}
+		if field := getUnknownTemplateField(t.Tree.Root, fields, true); field != "" {
+			names := []string{}
+			for f := range fields {
+				names = append(names, f)
+			}
+			sort.Strings(names)
+			return nil, merror.Stack(NewErrUnknownTemplateField(name, field, names))
+		}
+	}
+
+	return tmpl, nil
+}
+
+// getUnknownTemplateField returns the first field used on the template data that is not in fields.
+// Inside range and with blocks the dot is not the template data anymore, only $ is checked there.
+func getUnknownTemplateField(node parse.Node, fields map[string]bool, dotIsData bool) string {
+	children := []parse.Node{}
+	switch n := node.(type) {
+	case *parse.FieldNode:
+		if dotIsData && !fields[n.Ident[0]] {
+			return n.Ident[0]
+		}
+	case *parse.VariableNode:
+		if n.Ident[0] == "$" && len(n.Ident) > 1 && !fields[n.Ident[1]] {
+			return n.Ident[1]
+		}
+	case *parse.ChainNode:
+		children = append(children, n.Node)
+	case *parse.ListNode:
+		if n != nil {
+			children = append(children, n.Nodes...)
+		}
+	case *parse.ActionNode:
+		children = append(children, n.Pipe)
+	case *parse.PipeNode:
+		if n != nil {
+			for _, cmd := range n.Cmds {
+				children = append(children, cmd)
+			}
+		}
+	case *parse.CommandNode:
+		children = append(children, n.Args...)
+	case *parse.TemplateNode:
+		children = append(children, n.Pipe)
+	case *parse.IfNode:
+		children = append(children, n.Pipe, n.List, n.ElseList)
+	case *parse.RangeNode:
+		if field := getUnknownTemplateField(n.List, fields, false); field != "" {
+			return field
+		}
+		children = append(children, n.Pipe, n.ElseList)
+	case *parse.WithNode:
+		if field := getUnknownTemplateField(n.List, fields, false); field != "" {
+			return field
+		}
+		children = append(children, n.Pipe, n.ElseList)
+	}
+
+	for _, child := range children {
+		if field := getUnknownTemplateField(child, fields, dotIsData); field != "" {
+			return field
+		}
+	}
+	return ""
+}
@@ -3,6 +3,9 @@ package domainbuilder
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/cleogithub/golem-common/pkg/stringtool"
 	"github.com/cleogithub/golem/coredomaindefinition"
@@ -41,10 +44,17 @@ func GetCRUDRelationMethodName(ctx context.Context, action string, from *coredom
 	}
 }
 
-func GetValidationTags(ctx context.Context, validations []*coredomaindefinition.Validation) ([]string, error) {
+// validationParamEscaper escapes the separators of the validate tag in a rule parameter, as go-playground/validator reads them
+var validationParamEscaper = strings.NewReplacer(",", "0x2C", "|", "0x7C")
+
+// GetValidationTags returns the validate tags of a field.
+// Unique and uniqueIn are checked against the repository, mimetypes and maxSize on the file content, they have no tag.
+func GetValidationTags(ctx context.Context, field *coredomaindefinition.Field) ([]string, error) {
 	tags := make([]string, 0)
-	for _, validation := range validations {
+	for _, validation := range field.Validations {
 		switch validation.Rule {
+		case coredomaindefinition.ValidationRuleUnique, coredomaindefinition.ValidationRuleUniqueIn,
+			coredomaindefinition.ValidationRuleMIMETypes, coredomaindefinition.ValidationRuleMaxSize:
 		case coredomaindefinition.ValidationRuleRequired:
 			tags = append(tags, "required")
 		case coredomaindefinition.ValidationRuleEmail:
@@ -53,31 +63,101 @@ func GetValidationTags(ctx context.Context, validations []*coredomaindefinition.
 			tags = append(tags, "uuid")
 		case coredomaindefinition.ValidationRuleHexColor:
 			tags = append(tags, "hexcolor")
-		case coredomaindefinition.ValidationRuleGT:
-			if s, ok := validation.Value.(string); ok {
-				tags = append(tags, "gt:"+s)
-			} else {
-				return nil, NewErrValidationValueExpectedType(string(validation.Rule), "string")
+		case coredomaindefinition.ValidationRuleURL:
+			tags = append(tags, "url")
+		case coredomaindefinition.ValidationRuleIP:
+			tags = append(tags, "ip")
+		case coredomaindefinition.ValidationRuleAlphaNum:
+			tags = append(tags, "alphanum")
+		case coredomaindefinition.ValidationRuleGT, coredomaindefinition.ValidationRuleGTE, coredomaindefinition.ValidationRuleLT, coredomaindefinition.ValidationRuleLTE:
+			number, ok := validation.GetNumber()
+			if !ok {
+				return nil, NewErrValidationValueExpectedType(string(validation.Rule), "number")
 			}
-		case coredomaindefinition.ValidationRuleGTE:
-			if s, ok := validation.Value.(string); ok {
-				tags = append(tags, "gte:"+s)
-			} else {
-				return nil, NewErrValidationValueExpectedType(string(validation.Rule), "string")
+			tags = append(tags, string(validation.Rule)+"="+number)
+		case coredomaindefinition.ValidationRuleMinLength, coredomaindefinition.ValidationRuleMaxLength, coredomaindefinition.ValidationRuleLen:
+			length, ok := validation.GetLength()
+			if !ok {
+				return nil, NewErrValidationValueExpectedType(string(validation.Rule), "non negative integer")
 			}
-		case coredomaindefinition.ValidationRuleLT:
-			if s, ok := validation.Value.(string); ok {
-				tags = append(tags, "lt:"+s)
-			} else {
+			// min and max are lengths on strings, arrays and maps
+			tag := map[coredomaindefinition.ValidationRule]string{
+				coredomaindefinition.ValidationRuleMinLength: "min",
+				coredomaindefinition.ValidationRuleMaxLength: "max",
+				coredomaindefinition.ValidationRuleLen:       "len",
+			}[validation.Rule]
+			tags = append(tags, tag+"="+strconv.Itoa(length))
+		case coredomaindefinition.ValidationRulePattern:
+			pattern, ok := validation.Value.(string)
+			if !ok {
 				return nil, NewErrValidationValueExpectedType(string(validation.Rule), "string")
 			}
-		case coredomaindefinition.ValidationRuleLTE:
-			if s, ok := validation.Value.(string); ok {
-				tags = append(tags, "lte:"+s)
-			} else {
+			tags = append(tags, "pattern="+validationParamEscaper.Replace(pattern))
+		case coredomaindefinition.ValidationRuleOneOf:
+			values, ok := validation.GetValues()
+			if !ok {
+				return nil, NewErrValidationValueExpectedType(string(validation.Rule), "list")
+			}
+			// values are separated by spaces, single quotes keep the spaces of a value
+			values = slices.Clone(values)
+			for i, value := range values {
+				if strings.Contains(value, " ") {
+					values[i] = "'" + value + "'"
+				}
+				values[i] = validationParamEscaper.Replace(values[i])
+			}
+			tags = append(tags, "oneof="+strings.Join(values, " "))
+		case coredomaindefinition.ValidationRuleStartsWith, coredomaindefinition.ValidationRuleEndsWith:
+			value, ok := validation.Value.(string)
+			if !ok {
 				return nil, NewErrValidationValueExpectedType(string(validation.Rule), "string")
 			}
+			tags = append(tags, strings.ToLower(string(validation.Rule))+"="+validationParamEscaper.Replace(value))
+		default:
+			return nil, NewErrUnexpectedValidationRule(string(validation.Rule))
 		}
 	}
+	tags = append(tags, GetEnumValidationTags(ctx, field.Type)...)
+	isDecimal := field.Type == coredomaindefinition.PrimitiveTypeDecimal
+	if isDecimal {
+		tags = append(tags, "numeric")
+	}
+	tags = append(tags, GetCivilTimeValidationTags(ctx, field.Type)...)
+	isCivilTime := getCivilType(field.Type) != nil
+
+	// the amount and the currency of a money are both checked, a zero money is not provided
+	isMoney := field.Type == coredomaindefinition.PrimitiveTypeMoney
+
+	// an optional field, an enum, a decimal, a money, a date or a time of day value is only validated when provided
+	_, isEnum := field.Type.(*coredomaindefinition.Enum)
+	if (len(tags) > 0 || isMoney) && (field.Optional || isEnum || isDecimal || isMoney || isCivilTime) && !slices.Contains(tags, "required") {
+		tags = append([]string{"omitempty"}, tags...)
+	}
 	return tags, nil
 }
+
+// GetFileMIMETypes returns the MIME types accepted for a file field, DEFAULT_FILE_MIME_TYPES without mimetypes rule
+func GetFileMIMETypes(ctx context.Context, field *coredomaindefinition.Field) []string {
+	for _, validation := range field.Validations {
+		if validation.Rule != coredomaindefinition.ValidationRuleMIMETypes {
+			continue
+		}
+		if values, ok := validation.GetValues(); ok {
+			return values
+		}
+	}
+	return coredomaindefinition.DEFAULT_FILE_MIME_TYPES
+}
+
+// GetFileMaxSize returns the largest size in bytes accepted for a file field, DEFAULT_FILE_MAX_SIZE without maxSize rule
+func GetFileMaxSize(ctx context.Context, field *coredomaindefinition.Field) int64 {
+	for _, validation := range field.Validations {
+		if validation.Rule != coredomaindefinition.ValidationRuleMaxSize {
+			continue
+		}
+		if size, ok := validation.GetSize(); ok {
+			return size
+		}
+	}
+	return coredomaindefinition.DEFAULT_FILE_MAX_SIZE
+}
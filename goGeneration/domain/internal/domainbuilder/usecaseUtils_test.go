@@ -0,0 +1,102 @@
+package domainbuilder
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cleogithub/golem/coredomaindefinition"
+)
+
+func TestGetValidationTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		t           coredomaindefinition.Type
+		optional    bool
+		validations []*coredomaindefinition.Validation
+		expected    []string
+		err         string
+	}{
+		{
+			name: "lengths",
+			t:    coredomaindefinition.PrimitiveTypeString,
+			validations: []*coredomaindefinition.Validation{
+				{Rule: coredomaindefinition.ValidationRuleMinLength, Value: 2},
+				{Rule: coredomaindefinition.ValidationRuleMaxLength, Value: 10},
+				{Rule: coredomaindefinition.ValidationRuleLen, Value: 5},
+			},
+			expected: []string{"min=2", "max=10", "len=5"},
+		},
+		{
+			name: "formats",
+			t:    coredomaindefinition.PrimitiveTypeString,
+			validations: []*coredomaindefinition.Validation{
+				{Rule: coredomaindefinition.ValidationRuleURL},
+				{Rule: coredomaindefinition.ValidationRuleIP},
+				{Rule: coredomaindefinition.ValidationRuleAlphaNum},
+			},
+			expected: []string{"url", "ip", "alphanum"},
+		},
+		{
+			name: "separators of the tag are escaped",
+			t:    coredomaindefinition.PrimitiveTypeString,
+			validations: []*coredomaindefinition.Validation{
+				{Rule: coredomaindefinition.ValidationRulePattern, Value: "^[a-z]{1,3}|x$"},
+				{Rule: coredomaindefinition.ValidationRuleStartsWith, Value: "a,b"},
+				{Rule: coredomaindefinition.ValidationRuleEndsWith, Value: "z"},
+			},
+			expected: []string{"pattern=^[a-z]{10x2C3}0x7Cx$", "startswith=a0x2Cb", "endswith=z"},
+		},
+		{
+			name:        "values with spaces are quoted",
+			t:           coredomaindefinition.PrimitiveTypeString,
+			validations: []*coredomaindefinition.Validation{{Rule: coredomaindefinition.ValidationRuleOneOf, Value: []interface{}{"small", "extra large"}}},
+			expected:    []string{"oneof=small 'extra large'"},
+		},
+		{
+			name:        "an optional field is validated when provided",
+			t:           coredomaindefinition.PrimitiveTypeString,
+			optional:    true,
+			validations: []*coredomaindefinition.Validation{{Rule: coredomaindefinition.ValidationRuleURL}},
+			expected:    []string{"omitempty", "url"},
+		},
+		{
+			name: "required",
+			t:    coredomaindefinition.PrimitiveTypeString,
+			validations: []*coredomaindefinition.Validation{
+				{Rule: coredomaindefinition.ValidationRuleRequired},
+				{Rule: coredomaindefinition.ValidationRuleMinLength, Value: 2},
+			},
+			expected: []string{"required", "min=2"},
+		},
+		{
+			name:        "length of another type",
+			t:           coredomaindefinition.PrimitiveTypeString,
+			validations: []*coredomaindefinition.Validation{{Rule: coredomaindefinition.ValidationRuleMinLength, Value: "two"}},
+			err:         "non negative integer",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tags, err := GetValidationTags(context.Background(), &coredomaindefinition.Field{
+				Name:        "code",
+				Type:        test.t,
+				Optional:    test.optional,
+				Validations: test.validations,
+			})
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if !reflect.DeepEqual(tags, test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, tags)
+			}
+		})
+	}
+}
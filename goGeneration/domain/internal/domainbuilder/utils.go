@@ -30,6 +30,10 @@ func GetModelName(ctx context.Context, modelDefinition *coredomaindefinition.Mod
 	return stringtool.UpperFirstLetter(modelDefinition.Name)
 }
 
+func GetValueObjectName(ctx context.Context, valueObject *coredomaindefinition.ValueObject) string {
+	return stringtool.UpperFirstLetter(valueObject.Name)
+}
+
 func GetSingleRelationName(ctx context.Context, m *coredomaindefinition.Model) string {
 	return stringtool.UpperFirstLetter(m.Name)
 }
@@ -0,0 +1,95 @@
+package domainbuilder
+
+import (
+	"context"
+
+	"github.com/cleogithub/golem/goGeneration/domain/consts"
+	"github.com/cleogithub/golem/goGeneration/domain/model"
+)
+
+const (
+	FIELD_ERROR_NAME          = "FieldError"
+	VALIDATION_ERRORS_NAME    = "ValidationErrors"
+	TO_VALIDATION_ERRORS_NAME = "ToValidationErrors"
+)
+
+// addValidationErrors adds ValidationErrors, the payload of validation failures shared by the http controller and the sdk.
+// Each FieldError is serialized as {"field", "rule", "message", "params"}, field being the json path of the invalid field.
+func (builder *DomainUsecaseBuilder) addValidationErrors(ctx context.Context) {
+	if builder.Err != nil {
+		return
+	}
+
+	fieldError := &model.Struct{
+		Name:       FIELD_ERROR_NAME,
+		MethodName: "fieldError",
+		Fields: []*model.Field{
+			{Name: "Field", Type: model.PrimitiveTypeString, Tags: []*model.Tag{{Name: "json", Values: []string{"field"}}}},
+			{Name: "Rule", Type: model.PrimitiveTypeString, Tags: []*model.Tag{{Name: "json", Values: []string{"rule"}}}},
+			{Name: "Message", Type: model.PrimitiveTypeString, Tags: []*model.Tag{{Name: "json", Values: []string{"message"}}}},
+			{Name: "Params", Type: &model.ArrayType{Type: model.PrimitiveTypeString}, Tags: []*model.Tag{{Name: "json", Values: []string{"params"}}}},
+		},
+		Methods: []*model.Function{
+			{
+				Name:    "Error",
+				Results: []*model.Param{{Type: model.PrimitiveTypeString}},
+				Content: func() (string, []*model.GoPkg) {
+					return `return strings.TrimSpace(fieldError.Field + " " + fieldError.Message)` + consts.LN, []*model.GoPkg{consts.CommonPkgs["strings"]}
+				},
+			},
+		},
+	}
+
+	errs := &model.TypeDefinition{
+		Name: VALIDATION_ERRORS_NAME,
+		Type: &model.ArrayType{Type: &model.PointerType{Type: &model.ExternalType{Type: FIELD_ERROR_NAME}}},
+	}
+
+	builder.domainBuilder.Domain.Files = append(builder.domainBuilder.Domain.Files, &model.File{
+		Name: VALIDATION_ERRORS_NAME,
+		Pkg:  builder.domainBuilder.GetUsecasePackage(),
+		Elements: []interface{}{
+			fieldError,
+			errs,
+			&model.Function{
+				On:      errs,
+				OnName:  "validationErrors",
+				Name:    "Error",
+				Results: []*model.Param{{Type: model.PrimitiveTypeString}},
+				Content: func() (string, []*model.GoPkg) {
+					return `messages := []string{}
+for _, err := range validationErrors {
+	messages = append(messages, err.Error())
+}
+return strings.Join(messages, ", ")`, []*model.GoPkg{consts.CommonPkgs["strings"]}
+				},
+			},
+			&model.Function{
+				Comment: TO_VALIDATION_ERRORS_NAME + " returns the payload of a validation error answered by the http controllers.\n" +
+					"Errors wrapping ValidationErrors or a FieldError keep their fields, other errors, e.g. of a validation library,\n" +
+					"are a single invalid entry on no field: " + VALIDATOR_NAME + " implementations return ValidationErrors to report fields.",
+				Name:    TO_VALIDATION_ERRORS_NAME,
+				Args:    []*model.Param{{Name: "err", Type: model.PrimitiveTypeError}},
+				Results: []*model.Param{{Type: &model.ExternalType{Type: VALIDATION_ERRORS_NAME}}},
+				Content: func() (string, []*model.GoPkg) {
+					return `validationErrors := ValidationErrors{}
+var fieldError *FieldError
+if !errors.As(err, &validationErrors) {
+	if errors.As(err, &fieldError) {
+		validationErrors = ValidationErrors{fieldError}
+	} else {
+		validationErrors = ValidationErrors{{Rule: "invalid", Message: err.Error()}}
+	}
+}
+// params are always serialized as an array
+for _, e := range validationErrors {
+	if e.Params == nil {
+		e.Params = []string{}
+	}
+}
+return validationErrors`, []*model.GoPkg{consts.CommonPkgs["errors"]}
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,42 @@
+package domainbuilder
+
+import (
+	"testing"
+
+	"github.com/cleogithub/golem/coredomaindefinition"
+)
+
+func TestValidationErrorsGeneration(t *testing.T) {
+	shop := coredomaindefinition.NewModel("shop")
+	shop.Fields = []*coredomaindefinition.Field{
+		{Name: "name", Type: coredomaindefinition.PrimitiveTypeString},
+	}
+
+	files := generate(t, withCRUD(newTestDomain(shop), shop))
+
+	// the fields are reported with their json names
+	assertContains(t, files.goFile(t, "domain/usecase/validationErrors"),
+		"type FieldError struct {\n\tField   string   `json:\"field\"`\n\tRule    string   `json:\"rule\"`\n\tMessage string   `json:\"message\"`\n\tParams  []string `json:\"params\"`\n}",
+		"type ValidationErrors []*FieldError",
+		"func ToValidationErrors(err error) ValidationErrors {",
+		"validationErrors = ValidationErrors{{Rule: \"invalid\", Message: err.Error()}}",
+	)
+
+	// the controllers answer a validation error with a 422 and its fields
+	assertContains(t, goFunction(t, files.goFile(t, "adapter/controller/httpadapter/shopHttpController"), "func (shopHttpController *ShopHttpController) CreateShop("),
+		"if shopHttpController.Validator.IsValidationError(ctx, err) {\n\t\t\tw.Header().Set(\"Content-Type\", \"application/json\")\n\t\t\tw.WriteHeader(http.StatusUnprocessableEntity)\n\t\t\tjson.NewEncoder(w).Encode(usecase.ToValidationErrors(err))\n\t\t\treturn\n\t\t}",
+	)
+
+	// the go and js clients return them as errors
+	assertContains(t, goFunction(t, files.goFile(t, "sdk/client/shopHttpClient"), "func (shopHttpClient *ShopHttpClient) CreateShop("),
+		"if status == http.StatusUnprocessableEntity {\n\t\tvalidationErrors := usecase.ValidationErrors{}\n\t\tif err = json.Unmarshal(resp, &validationErrors); err != nil {\n\t\t\treturn nil, err\n\t\t}\n\t\treturn nil, validationErrors\n\t}",
+	)
+	assertContains(t, files.jsFile(t, "validationErrors"),
+		"export class ValidationErrors extends Error {",
+		"static from(data) { return new ValidationErrors(Array.isArray(data) ? data : []) }",
+	)
+	assertContains(t, files.jsFile(t, "shopService"),
+		"\tValidationErrors\n} from './';",
+		"if (error.response && error.response.status === 422) {\n\t\t\t\t\treject(ValidationErrors.from(error.response.data))\n\t\t\t\t\treturn\n\t\t\t\t}",
+	)
+}
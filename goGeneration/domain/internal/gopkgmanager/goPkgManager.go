@@ -2,6 +2,7 @@ package gopkgmanager
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/cleogithub/golem/goGeneration/domain/consts"
 	"github.com/cleogithub/golem/goGeneration/domain/model"
@@ -15,9 +16,20 @@ type GoPkgManager struct {
 func (g *GoPkgManager) ToString() string {
 	str := fmt.Sprintf("package %s\n", g.Pkg)
 
-	// Print all imports
+	// Print all imports, sorted for a deterministic output
+	fullNames := make([]string, 0, len(g.Imports))
+	for fullName := range g.Imports {
+		fullNames = append(fullNames, fullName)
+	}
+	sort.Strings(fullNames)
+	// an empty import block is removed by gofmt -s, go/format keeps it
+	if len(fullNames) == 0 {
+		return str
+	}
+
 	str += "import (\n"
-	for _, goPkg := range g.Imports {
+	for _, fullName := range fullNames {
+		goPkg := g.Imports[fullName]
 		alias := ""
 		if goPkg.Alias != goPkg.ShortName {
 			alias = goPkg.Alias
@@ -38,7 +50,7 @@ func (g *GoPkgManager) ImportPkg(goPkg *model.GoPkg) error {
 		return nil
 	}
 	// Check if goPkg is already imported
-	if _, ok := g.Imports[goPkg.Alias]; !ok {
+	if _, ok := g.Imports[goPkg.FullName]; !ok {
 		g.Imports[goPkg.FullName] = goPkg
 	}
 
@@ -0,0 +1,29 @@
+package stringifier
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cleogithub/golem/goGeneration/domain/consts"
+	"github.com/cleogithub/golem/goGeneration/domain/internal/gopkgmanager"
+)
+
+// StringifyCommentUsecase returns the line comments of a doc comment, lines indented with a tab are kept as code blocks
+func StringifyCommentUsecase(ctx context.Context, pkgManager *gopkgmanager.GoPkgManager, comment string) string {
+	if comment == "" {
+		return ""
+	}
+
+	str := ""
+	for _, line := range strings.Split(comment, "\n") {
+		switch {
+		case line == "":
+			str += "//" + consts.LN
+		case strings.HasPrefix(line, "\t"):
+			str += "//" + line + consts.LN
+		default:
+			str += "// " + line + consts.LN
+		}
+	}
+	return str
+}
@@ -3,6 +3,7 @@ package stringifier
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/cleogithub/golem/goGeneration/domain/consts"
 	"github.com/cleogithub/golem/goGeneration/domain/internal/gopkgmanager"
@@ -12,8 +13,16 @@ import (
 func StringifyEnumUsecase(ctx context.Context, pkgManager *gopkgmanager.GoPkgManager, enum *model.Enum) (string, error) {
 	t := enum.Type.GetType(model.InPkg(pkgManager.Pkg))
 
+	// sort keys for a deterministic output
+	keys := make([]string, 0, len(enum.Values))
+	for key := range enum.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
 	str := "const (" + consts.LN
-	for key, value := range enum.Values {
+	for _, key := range keys {
+		value := enum.Values[key]
 		if _, ok := value.(string); ok {
 			value = fmt.Sprintf(`"%v"`, value)
 		}
@@ -21,7 +21,8 @@ func StringifyFunctionUsecase(ctx context.Context, pkgManager *gopkgmanager.GoPk
 		on = fmt.Sprintf("(%s %s)", function.OnName, function.On.GetType(model.InPkg(pkgManager.Pkg)))
 	}
 
-	str := fmt.Sprintf("func %s %s {", on, def) + consts.LN
+	str := StringifyCommentUsecase(ctx, pkgManager, function.Comment)
+	str += fmt.Sprintf("func %s %s {", on, def) + consts.LN
 	s, pkgs := function.Content()
 	for _, pkg := range pkgs {
 		if err := pkgManager.ImportPkg(pkg); err != nil {
@@ -11,12 +11,14 @@ import (
 )
 
 func StringifyInterfaceUsecase(ctx context.Context, pkgManager *gopkgmanager.GoPkgManager, itf *model.Interface) (string, error) {
-	str := fmt.Sprintf("type %s interface {", itf.Name) + consts.LN
+	str := StringifyCommentUsecase(ctx, pkgManager, itf.Comment)
+	str += fmt.Sprintf("type %s interface {", itf.Name) + consts.LN
 	for _, method := range itf.Methods {
 		s, err := StringifyFunctionDefinitionUsecase(ctx, pkgManager, method)
 		if err != nil {
 			return "", merror.Stack(err)
 		}
+		str += StringifyCommentUsecase(ctx, pkgManager, method.Comment)
 		str += s + consts.LN
 	}
 	str += "}"
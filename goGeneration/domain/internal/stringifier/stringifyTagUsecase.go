@@ -3,6 +3,7 @@ package stringifier
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/cleogithub/golem-common/pkg/merror"
 	"github.com/cleogithub/golem/goGeneration/domain/internal/gopkgmanager"
@@ -10,11 +11,19 @@ import (
 )
 
 func StringifyTagUsecase(ctx context.Context, pkgManager *gopkgmanager.GoPkgManager, tag *model.Tag) (string, error) {
+	// gorm settings are separated by semicolons, e.g. `gorm:"column:deleted_at;index"`
+	separator := ","
+	if tag.Name == "gorm" {
+		separator = ";"
+	}
+
+	// the tag is read with strconv.Unquote, e.g. the \; separator escape of gorm is written \\;
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
 	str := ""
 	for idx, value := range tag.Values {
-		str += value
+		str += escaper.Replace(value)
 		if idx < len(tag.Values)-1 {
-			str += ","
+			str += separator
 		}
 	}
 	str = fmt.Sprintf(`%s:"%s"`, tag.Name, str)
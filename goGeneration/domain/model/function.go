@@ -1,6 +1,8 @@
 package model
 
 type Function struct {
+	// Comment is the doc comment of the function, without the leading slashes
+	Comment string
 	On      Type
 	OnName  string
 	Name    string
@@ -46,6 +48,7 @@ func (t Function) SubTypes() []Type {
 // Add copy function
 func (t *Function) Copy() *Function {
 	return &Function{
+		Comment: t.Comment,
 		Name:    t.Name,
 		Args:    Params(t.Args).Copy(),
 		Results: Params(t.Results).Copy(),
@@ -1,6 +1,8 @@
 package model
 
 type Interface struct {
+	// Comment is the doc comment of the interface, without the leading slashes
+	Comment string
 	Name    string
 	Methods []*Function
 }
@@ -15,6 +17,7 @@ func (i *Interface) SubTypes() []Type {
 
 func (i *Interface) Copy() *Interface {
 	return &Interface{
+		Comment: i.Comment,
 		Name:    i.Name,
 		Methods: Functions(i.Methods).Copy(),
 	}
@@ -0,0 +1,48 @@
+package plugin
+
+import (
+	"context"
+
+	"github.com/cleogithub/golem/coredomaindefinition"
+	"github.com/cleogithub/golem/goGeneration/domain/model"
+)
+
+// Builder receives every definition of the domain, then Build is called once, after the golem builders.
+// Files appended to the domain in Build are written with the generated ones.
+type Builder interface {
+	WithModel(ctx context.Context, definition *coredomaindefinition.Model)
+	WithRepository(ctx context.Context, definition *coredomaindefinition.Repository)
+	WithRelation(ctx context.Context, definition *coredomaindefinition.Relation)
+	WithCRUD(ctx context.Context, definition *coredomaindefinition.CRUD)
+	WithUsecase(ctx context.Context, definition *coredomaindefinition.Usecase)
+	Build(ctx context.Context) error
+}
+
+// BuilderFactory creates a Builder for the domain being generated.
+// domain is shared with golem builders: its Architecture is set and Models and Files are filled on Build.
+type BuilderFactory func(ctx context.Context, definition *coredomaindefinition.Domain, domain *model.Domain) Builder
+
+// EmptyBuilder can be embedded to implement only the needed callbacks
+type EmptyBuilder struct {
+}
+
+var _ Builder = &EmptyBuilder{}
+
+func (builder *EmptyBuilder) WithModel(ctx context.Context, definition *coredomaindefinition.Model) {
+}
+
+func (builder *EmptyBuilder) WithRepository(ctx context.Context, definition *coredomaindefinition.Repository) {
+}
+
+func (builder *EmptyBuilder) WithRelation(ctx context.Context, definition *coredomaindefinition.Relation) {
+}
+
+func (builder *EmptyBuilder) WithCRUD(ctx context.Context, definition *coredomaindefinition.CRUD) {
+}
+
+func (builder *EmptyBuilder) WithUsecase(ctx context.Context, definition *coredomaindefinition.Usecase) {
+}
+
+func (builder *EmptyBuilder) Build(ctx context.Context) error {
+	return nil
+}
@@ -23,6 +23,12 @@ var (
 
 	// ErrUnknowTypeToWrite is returned when the type is unknown on write usecase call
 	ErrUnknowTypeToWrite = errors.New("unknown type to write")
+
+	// ErrInvalidGeneratedFile is returned when a generated go file can not be formatted
+	ErrInvalidGeneratedFile = errors.New("invalid generated file {position}: {error}")
+
+	// ErrGoModTidy is returned when go mod tidy fails on a generated module
+	ErrGoModTidy = errors.New("go mod tidy failed: {error}")
 )
 
 func NewErrUnknownType(t string) error {
@@ -50,3 +56,15 @@ func NewErrRelationModelNotFound(model string) error {
 	str := strings.Replace(ErrRelationModelNotFound.Error(), "{model}", model, 1)
 	return errors.New(str)
 }
+
+func NewErrInvalidGeneratedFile(position string, err string) error {
+	str := strings.Replace(ErrInvalidGeneratedFile.Error(), "{position}", position, 1)
+	return errors.New(strings.Replace(str, "{error}", err, 1))
+}
+
+func NewErrGoModTidy(output string, err error) error {
+	if output == "" {
+		output = err.Error()
+	}
+	return errors.New(strings.Replace(ErrGoModTidy.Error(), "{error}", output, 1))
+}
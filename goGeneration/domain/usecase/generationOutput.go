@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/cleogithub/golem-common/pkg/merror"
+	"github.com/cleogithub/golem/goGeneration/domain/consts"
+	"github.com/cleogithub/golem/goGeneration/domain/filesystem"
+)
+
+// Manifest lists the files generated in a module, it is written at the module root
+type Manifest struct {
+	Files []*ManifestFile `json:"files"`
+}
+
+type ManifestFile struct {
+	// Path is relative to the module root
+	Path string `json:"path"`
+	// Hash is the hex encoded sha256 of the content
+	Hash string `json:"hash"`
+}
+
+// generationOutput writes the generated files of a module and keeps track of them for the manifest
+type generationOutput struct {
+	fs    filesystem.FileSystem
+	root  string
+	files map[string]string
+}
+
+func newGenerationOutput(fs filesystem.FileSystem, root string) *generationOutput {
+	return &generationOutput{
+		fs:    fs,
+		root:  path.Clean(root),
+		files: map[string]string{},
+	}
+}
+
+// WriteFile records the file in the manifest and writes it if its content changed.
+// Files outside the module root, like a javascript client folder in another project, are written but not recorded,
+// so they are never removed by a later generation.
+func (o *generationOutput) WriteFile(name string, content []byte) error {
+	if relative, ok := o.relativePath(name); ok {
+		hash := sha256.Sum256(content)
+		o.files[relative] = hex.EncodeToString(hash[:])
+	}
+
+	if err := writeIfChanged(o.fs, name, content); err != nil {
+		return merror.Stack(err)
+	}
+	return nil
+}
+
+// Commit removes the files of the previous manifest which are no more generated, then writes the new manifest.
+// Without previous manifest, every file containing GENERATED_FILE_MARK is considered as generated.
+func (o *generationOutput) Commit(ctx context.Context) error {
+	previous, err := o.previousFiles(ctx)
+	if err != nil {
+		return merror.Stack(err)
+	}
+
+	for _, name := range previous {
+		if _, ok := o.files[name]; ok {
+			continue
+		}
+		exists, err := o.fs.Exists(o.root + "/" + name)
+		if err != nil {
+			return merror.Stack(err)
+		}
+		if exists {
+			if err := o.fs.Remove(o.root + "/" + name); err != nil {
+				return merror.Stack(err)
+			}
+		}
+	}
+
+	manifest := &Manifest{
+		Files: []*ManifestFile{},
+	}
+	for name, hash := range o.files {
+		manifest.Files = append(manifest.Files, &ManifestFile{
+			Path: name,
+			Hash: hash,
+		})
+	}
+	sort.Slice(manifest.Files, func(i, j int) bool {
+		return manifest.Files[i].Path < manifest.Files[j].Path
+	})
+
+	content, err := json.MarshalIndent(manifest, "", "\t")
+	if err != nil {
+		return merror.Stack(err)
+	}
+	if err := writeIfChanged(o.fs, o.root+"/"+consts.MANIFEST_FILE, append(content, '\n')); err != nil {
+		return merror.Stack(err)
+	}
+
+	return nil
+}
+
+// previousFiles returns the paths, relative to root, generated by the previous generation
+func (o *generationOutput) previousFiles(ctx context.Context) ([]string, error) {
+	files := []string{}
+
+	manifestPath := o.root + "/" + consts.MANIFEST_FILE
+	exists, err := o.fs.Exists(manifestPath)
+	if err != nil {
+		return nil, merror.Stack(err)
+	}
+	if exists {
+		content, err := o.fs.ReadFile(manifestPath)
+		if err != nil {
+			return nil, merror.Stack(err)
+		}
+		manifest := &Manifest{}
+		if err := json.Unmarshal(content, manifest); err != nil {
+			return nil, merror.Stack(err)
+		}
+		for _, f := range manifest.Files {
+			// a manifest edited by hand must not remove files outside the module
+			if isInModule(f.Path) {
+				files = append(files, path.Clean(f.Path))
+			}
+		}
+		return files, nil
+	}
+
+	paths, err := o.fs.ListFiles(o.root)
+	if err != nil {
+		return nil, merror.Stack(err)
+	}
+	for _, name := range paths {
+		name = path.Clean(name)
+		if strings.Contains(path.Base(name), consts.GENERATED_FILE_MARK) {
+			files = append(files, strings.TrimPrefix(name, o.root+"/"))
+		}
+	}
+	return files, nil
+}
+
+// relativePath returns the path of name relative to the module root, false if name is outside of it
+func (o *generationOutput) relativePath(name string) (string, bool) {
+	relative := path.Clean(name)
+	if o.root != "." {
+		if !strings.HasPrefix(relative, o.root+"/") {
+			return "", false
+		}
+		relative = strings.TrimPrefix(relative, o.root+"/")
+	}
+	return relative, isInModule(relative)
+}
+
+// isInModule reports whether the path, relative to the module root, stays in the module
+func isInModule(relative string) bool {
+	relative = path.Clean(relative)
+	return relative != ".." && !strings.HasPrefix(relative, "../") && !path.IsAbs(relative)
+}
+
+// writeIfChanged writes the file unless it already exists with the same content
+func writeIfChanged(fs filesystem.FileSystem, name string, content []byte) error {
+	exists, err := fs.Exists(name)
+	if err != nil {
+		return merror.Stack(err)
+	}
+	if exists {
+		old, err := fs.ReadFile(name)
+		if err != nil {
+			return merror.Stack(err)
+		}
+		if bytes.Equal(old, content) {
+			return nil
+		}
+	}
+
+	if err := fs.WriteFile(name, content); err != nil {
+		return merror.Stack(err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/cleogithub/golem/goGeneration/domain/consts"
+	"github.com/cleogithub/golem/goGeneration/domain/filesystem"
+)
+
+func TestGenerationOutputManifest(t *testing.T) {
+	tests := []struct {
+		name string
+		root string
+		// files of the previous generation, written before the new one
+		previous []string
+		written  []string
+		// expected manifest paths
+		manifest []string
+		// files expected on the file system after Commit
+		exists  []string
+		removed []string
+	}{
+		{
+			name:     "files relative to the root",
+			root:     "out/shop",
+			written:  []string{"out/shop/model/user.golem.go", "out/shop//sdk/js/index.js"},
+			manifest: []string{"model/user.golem.go", "sdk/js/index.js"},
+		},
+		{
+			name:     "files outside the root are not recorded",
+			root:     "out/shop",
+			written:  []string{"out/shop/model/user.golem.go", "out/shop/../web/user.golem.js", "front/index.js"},
+			manifest: []string{"model/user.golem.go"},
+			exists:   []string{"out/web/user.golem.js", "front/index.js"},
+		},
+		{
+			name:     "current folder as root",
+			root:     ".",
+			written:  []string{"model/user.golem.go", "../web/user.golem.js"},
+			manifest: []string{"model/user.golem.go"},
+		},
+		{
+			name:     "files no more generated are removed",
+			root:     "out/shop",
+			previous: []string{"model/user.golem.go", "model/shop.golem.go"},
+			written:  []string{"out/shop/model/user.golem.go"},
+			manifest: []string{"model/user.golem.go"},
+			removed:  []string{"out/shop/model/shop.golem.go"},
+		},
+		{
+			name:     "previous files outside the root are kept",
+			root:     "out/shop",
+			previous: []string{"../web/user.golem.js"},
+			manifest: []string{},
+			exists:   []string{"out/web/user.golem.js"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			fs := filesystem.NewMemoryFileSystem(nil)
+
+			if test.previous != nil {
+				files := []*ManifestFile{}
+				for _, name := range test.previous {
+					fs.WriteFile(test.root+"/"+name, []byte(name))
+					files = append(files, &ManifestFile{Path: name})
+				}
+				content, _ := json.Marshal(&Manifest{Files: files})
+				fs.WriteFile(test.root+"/"+consts.MANIFEST_FILE, content)
+			}
+
+			output := newGenerationOutput(fs, test.root)
+			for _, name := range test.written {
+				if err := output.WriteFile(name, []byte(name)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := output.Commit(ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			content, err := fs.ReadFile(test.root + "/" + consts.MANIFEST_FILE)
+			if err != nil {
+				t.Fatal(err)
+			}
+			manifest := &Manifest{}
+			if err := json.Unmarshal(content, manifest); err != nil {
+				t.Fatal(err)
+			}
+			if len(manifest.Files) != len(test.manifest) {
+				t.Fatalf("expected %v, got %s", test.manifest, content)
+			}
+			for i, expected := range test.manifest {
+				if manifest.Files[i].Path != expected {
+					t.Errorf("expected %s, got %s", expected, manifest.Files[i].Path)
+				}
+			}
+
+			for _, name := range test.exists {
+				if exists, _ := fs.Exists(name); !exists {
+					t.Errorf("expected %s to exist", name)
+				}
+			}
+			for _, name := range test.removed {
+				if exists, _ := fs.Exists(name); exists {
+					t.Errorf("expected %s to be removed", name)
+				}
+			}
+		})
+	}
+}
@@ -4,8 +4,15 @@ import (
 	"context"
 
 	"github.com/cleogithub/golem/coredomaindefinition"
+	"github.com/cleogithub/golem/goGeneration/domain/filesystem"
 )
 
 type GenerationUsecase interface {
 	GenerateDomainUsecase(ctx context.Context, domainDefinition coredomaindefinition.Domain, path string) error
+	// GeneratePlatformUsecase generates every domain of the platform, the shared roles package and a go.work tying them together.
+	GeneratePlatformUsecase(ctx context.Context, platformDefinition coredomaindefinition.Platform, path string) error
+	// DryRunDomainUsecase generates the domain in memory and returns the files it would add, modify or delete under path.
+	DryRunDomainUsecase(ctx context.Context, domainDefinition coredomaindefinition.Domain, path string) ([]*filesystem.FileChange, error)
+	// ValidateDomainUsecase runs the builders on the definition without writing anything.
+	ValidateDomainUsecase(ctx context.Context, domainDefinition coredomaindefinition.Domain) error
 }
@@ -1,206 +1,222 @@
 package usecase
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
-	"os"
+	"go/format"
+	"go/scanner"
 	"os/exec"
+	"sort"
 	"strings"
 
 	"github.com/cleogithub/golem-common/pkg/merror"
 	"github.com/cleogithub/golem-common/pkg/stringtool"
 	"github.com/cleogithub/golem/coredomaindefinition"
 	"github.com/cleogithub/golem/goGeneration/domain/consts"
+	"github.com/cleogithub/golem/goGeneration/domain/filesystem"
 	"github.com/cleogithub/golem/goGeneration/domain/internal/domainbuilder"
 	"github.com/cleogithub/golem/goGeneration/domain/internal/gopkgmanager"
 	"github.com/cleogithub/golem/goGeneration/domain/internal/stringifier"
 	"github.com/cleogithub/golem/goGeneration/domain/model"
+	"github.com/cleogithub/golem/goGeneration/domain/plugin"
 )
 
 type GenerationUsecaseImpl struct {
+	// FileSystem is the output of the generation, files are written on disk if nil
+	FileSystem filesystem.FileSystem
+
+	// Builders are custom builders added to golem ones for every generated domain
+	Builders []plugin.BuilderFactory
+
+	// Tidy runs go mod tidy on generated modules, it needs the go binary and network access.
+	// Without it the module builds with the requirements and checksums pinned in go.mod and go.sum.
+	Tidy bool
 }
 
 var _ GenerationUsecase = &GenerationUsecaseImpl{}
 
 // GenerateDomainUsecase implements GenerationUsecase.
 func (g *GenerationUsecaseImpl) GenerateDomainUsecase(ctx context.Context, domainDefinition coredomaindefinition.Domain, path string) error {
-	domainBuilder := domainbuilder.NewDomainBuilder(
-		ctx,
-		&domainDefinition,
-		consts.DefaultModelFields,
-	)
-
-	for _, m := range domainDefinition.Models {
-		domainBuilder.WithModel(ctx, m)
-	}
-
-	for _, r := range domainDefinition.Relations {
-		domainBuilder.WithRelation(ctx, r)
-	}
-
-	for _, r := range domainDefinition.Repositories {
-		domainBuilder.WithRepository(ctx, r)
-	}
-
-	for _, r := range domainDefinition.CRUDs {
-		domainBuilder.WithCRUD(ctx, r)
-	}
-
-	for _, r := range domainDefinition.Usecases {
-		domainBuilder.WithUsecase(ctx, r)
-	}
-
-	domain, err := domainBuilder.Build(ctx)
+	domain, err := g.buildDomainUsecase(ctx, &domainDefinition)
 	if err != nil {
 		return merror.Stack(err)
 	}
-	_ = domain
-
-	if err := g.removeGenerationsUsecase(ctx, stringtool.RemoveDuplicate(path+"/"+domain.Name, '/')); err != nil {
-		return merror.Stack(err)
-	}
 
 	if err := g.initDomainUsecase(ctx, &domainDefinition, path); err != nil {
 		return merror.Stack(err)
 	}
 
+	output := newGenerationOutput(g.getFileSystem(), stringtool.RemoveDuplicate(path+"/"+domain.Name, '/'))
+
 	for _, m := range domain.Models {
-		if err := g.write(ctx, &domainDefinition, domain.Architecture.ModelPkg, m, path); err != nil {
+		if err := g.write(ctx, output, &domainDefinition, domain.Architecture.ModelPkg, m, path); err != nil {
 			return merror.Stack(err)
 		}
 	}
 
 	for _, port := range domain.Files {
-		if err := g.write(ctx, &domainDefinition, port.Pkg, port, path); err != nil {
+		if err := g.write(ctx, output, &domainDefinition, port.Pkg, port, path); err != nil {
 			return merror.Stack(err)
 		}
 	}
 
-	if err := g.generateJavascriptClientUsecase(ctx, domain, path); err != nil {
+	if err := g.generateJavascriptClientUsecase(ctx, output, domain, path); err != nil {
 		return merror.Stack(err)
 	}
 
-	if err := g.formatDomainUsecase(ctx, &domainDefinition, path); err != nil {
+	// remove orphans of the previous generation and write the manifest
+	if err := output.Commit(ctx); err != nil {
 		return merror.Stack(err)
 	}
 
-	if err := g.goTidyDomainUsecase(ctx, path, &domainDefinition); err != nil {
-		return merror.Stack(err)
+	// tidy needs the module on disk
+	if _, ok := g.getFileSystem().(*filesystem.OSFileSystem); ok && g.Tidy {
+		if err := g.goTidyDomainUsecase(ctx, path, &domainDefinition); err != nil {
+			return merror.Stack(err)
+		}
 	}
 
 	return nil
 }
 
-func (g *GenerationUsecaseImpl) initDomainUsecase(ctx context.Context, domain *coredomaindefinition.Domain, path string) error {
-	// if go.mod file does not exist at root folder, create it
-	//check path exist or create if
-	if _, err := os.Stat(path + "/" + domain.Name); os.IsNotExist(err) {
-		if err := os.MkdirAll(path+"/"+domain.Name, os.ModePerm); err != nil {
-			return merror.Stack(err)
-		}
-	}
+// DryRunDomainUsecase implements GenerationUsecase.
+func (g *GenerationUsecaseImpl) DryRunDomainUsecase(ctx context.Context, domainDefinition coredomaindefinition.Domain, path string) ([]*filesystem.FileChange, error) {
+	overlay := filesystem.NewMemoryFileSystem(g.getFileSystem())
 
-	// init go.mod file if not exist
-	if _, err := os.Stat(path + "/" + domain.Name + "/go.mod"); os.IsNotExist(err) {
-		// create go.mod file
-		cmd := exec.Command("go", "mod", "init", domain.Configuration.Package)
-		cmd.Dir = path + "/" + domain.Name
-		if err := cmd.Run(); err != nil {
-			return merror.Stack(err)
-		}
+	dryRun := *g
+	dryRun.FileSystem = overlay
+	if err := dryRun.GenerateDomainUsecase(ctx, domainDefinition, path); err != nil {
+		return nil, merror.Stack(err)
 	}
 
-	return nil
+	changes, err := overlay.Changes()
+	if err != nil {
+		return nil, merror.Stack(err)
+	}
+	return changes, nil
 }
 
-func (g *GenerationUsecaseImpl) goTidyDomainUsecase(ctx context.Context, path string, domain *coredomaindefinition.Domain) error {
-	cmd := exec.Command("go", "mod", "tidy")
-	cmd.Dir = path + "/" + domain.Name
-
-	if err := cmd.Run(); err != nil {
-		return merror.Stack(err)
+func (g *GenerationUsecaseImpl) getFileSystem() filesystem.FileSystem {
+	if g.FileSystem == nil {
+		return &filesystem.OSFileSystem{}
 	}
-
-	return nil
+	return g.FileSystem
 }
 
-func (u GenerationUsecaseImpl) formatDomainUsecase(ctx context.Context, domain *coredomaindefinition.Domain, path string) error {
-	// use command gofmt to format go files in generation folder
-	cmd := exec.Command("gofmt", "-w", "-s", path+"/"+domain.Name)
-	errWriter := bytes.NewBufferString("")
-	cmd.Stderr = errWriter
-	if err := cmd.Run(); err != nil {
-		fmt.Printf("Error: %v\n", errWriter.String())
+// ValidateDomainUsecase implements GenerationUsecase.
+func (g *GenerationUsecaseImpl) ValidateDomainUsecase(ctx context.Context, domainDefinition coredomaindefinition.Domain) error {
+	if _, err := g.buildDomainUsecase(ctx, &domainDefinition); err != nil {
 		return merror.Stack(err)
 	}
 	return nil
 }
 
-// GenerateDomainUsecase implements GenerationUsecase.
-func (g *GenerationUsecaseImpl) removeGenerationsUsecase(ctx context.Context, path string) error {
-	// go deep through the domain and remove all generated filed ending with golem.go
+func (g *GenerationUsecaseImpl) buildDomainUsecase(ctx context.Context, domainDefinition *coredomaindefinition.Domain) (*model.Domain, error) {
+	// report every definition problem at once instead of failing deep in the builders
+	if err := domainDefinition.Validate(); err != nil {
+		return nil, merror.Stack(err)
+	}
+
+	domainBuilder := domainbuilder.NewDomainBuilder(
+		ctx,
+		domainDefinition,
+		consts.DefaultModelFields,
+	)
 
-	// if path does not exist, return
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		return nil
+	for _, m := range domainDefinition.Models {
+		domainBuilder.WithModel(ctx, m)
+	}
+
+	for _, r := range domainDefinition.Relations {
+		domainBuilder.WithRelation(ctx, r)
 	}
 
-	// list all files in the path
-	files, err := os.ReadDir(path)
+	for _, r := range domainDefinition.Repositories {
+		domainBuilder.WithRepository(ctx, r)
+	}
+
+	for _, r := range domainDefinition.CRUDs {
+		domainBuilder.WithCRUD(ctx, r)
+	}
+
+	for _, r := range domainDefinition.Usecases {
+		domainBuilder.WithUsecase(ctx, r)
+	}
+
+	// custom builders are added last so they are built after golem ones, definitions are replayed on add
+	for _, factory := range g.Builders {
+		domainBuilder.AddBuilder(ctx, factory(ctx, domainDefinition, domainBuilder.Domain))
+	}
+
+	domain, err := domainBuilder.Build(ctx)
+	if err != nil {
+		return nil, merror.Stack(err)
+	}
+
+	return domain, nil
+}
+
+func (g *GenerationUsecaseImpl) initDomainUsecase(ctx context.Context, domain *coredomaindefinition.Domain, path string) error {
+	// init go.mod file if not exist, otherwise only add the missing requirements
+	gomod := stringtool.RemoveDuplicate(path+"/"+domain.Name+"/"+consts.GO_MOD_FILE, '/')
+	exists, err := g.getFileSystem().Exists(gomod)
 	if err != nil {
 		return merror.Stack(err)
 	}
 
-	for _, f := range files {
-		if !f.IsDir() && (strings.Contains(f.Name(), ".golem.")) {
-			if err := os.Remove(path + "/" + f.Name()); err != nil {
-				return merror.Stack(err)
-			}
-		} else if f.IsDir() {
-			if err := removeGenerationInFolder(ctx, path+"/"+f.Name()); err != nil {
-				return merror.Stack(err)
-			}
+	content := goModContent(domain.Configuration.Package, consts.GoModRequirements, consts.GoModIndirectRequirements)
+	if exists {
+		content, err = g.getFileSystem().ReadFile(gomod)
+		if err != nil {
+			return merror.Stack(err)
 		}
+		content = addGoModRequirements(content, consts.GoModRequirements, consts.GoModIndirectRequirements)
 	}
 
-	return nil
-}
+	if err := writeIfChanged(g.getFileSystem(), gomod, content); err != nil {
+		return merror.Stack(err)
+	}
 
-func removeGenerationInFolder(ctx context.Context, path string) error {
-	files, err := os.ReadDir(path)
+	// the checksums of the pinned modules let the module build without go mod tidy
+	gosum := stringtool.RemoveDuplicate(path+"/"+domain.Name+"/"+consts.GO_SUM_FILE, '/')
+	exists, err = g.getFileSystem().Exists(gosum)
 	if err != nil {
 		return merror.Stack(err)
 	}
 
-	for _, f := range files {
-		if !f.IsDir() && (strings.Contains(f.Name(), ".golem.")) {
-			fmt.Printf("Removing generated file: %s\n", path+"/"+f.Name())
-			if err := os.Remove(path + "/" + f.Name()); err != nil {
-				return merror.Stack(err)
-			}
-		} else if f.IsDir() {
-			if err := removeGenerationInFolder(ctx, path+"/"+f.Name()); err != nil {
-				return merror.Stack(err)
-			}
+	content = []byte{}
+	if exists {
+		content, err = g.getFileSystem().ReadFile(gosum)
+		if err != nil {
+			return merror.Stack(err)
 		}
 	}
+
+	if err := writeIfChanged(g.getFileSystem(), gosum, addGoSumLines(content, consts.GoSum)); err != nil {
+		return merror.Stack(err)
+	}
+
 	return nil
 }
 
-func (g *GenerationUsecaseImpl) write(ctx context.Context, domain *coredomaindefinition.Domain, inPkg *model.GoPkg, elem interface{}, path string) (err error) {
-	// if file path does not exist, create it
-	filepath := path + "/" + strings.ReplaceAll(inPkg.FullName, domain.Configuration.Package, domain.Name)
-	filepath = stringtool.RemoveDuplicate(filepath, '/')
-	// fmt.Printf("Writing to file: %s\n", filepath)
-	// return nil
+func (g *GenerationUsecaseImpl) goTidyDomainUsecase(ctx context.Context, path string, domain *coredomaindefinition.Domain) error {
+	cmd := exec.CommandContext(ctx, "go", "mod", "tidy")
+	cmd.Dir = path + "/" + domain.Name
 
-	if _, err := os.Stat(filepath); os.IsNotExist(err) {
-		if err := os.MkdirAll(filepath, os.ModePerm); err != nil {
-			return merror.Stack(err)
-		}
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return merror.Stack(NewErrGoModTidy(strings.TrimSpace(string(output)), err))
 	}
 
+	return nil
+}
+
+func (g *GenerationUsecaseImpl) write(ctx context.Context, output *generationOutput, domain *coredomaindefinition.Domain, inPkg *model.GoPkg, elem interface{}, path string) (err error) {
+	filepath := path + "/" + strings.ReplaceAll(inPkg.FullName, domain.Configuration.Package, domain.Name)
+	return g.writeInFolder(ctx, output, stringtool.RemoveDuplicate(filepath, '/'), inPkg, elem)
+}
+
+func (g *GenerationUsecaseImpl) writeInFolder(ctx context.Context, output *generationOutput, filepath string, inPkg *model.GoPkg, elem interface{}) (err error) {
 	pkgManager := &gopkgmanager.GoPkgManager{
 		Pkg: inPkg.ShortName,
 	}
@@ -233,59 +249,63 @@ func (g *GenerationUsecaseImpl) write(ctx context.Context, domain *coredomaindef
 		return merror.Stack(ErrUnknowTypeToWrite)
 	}
 
-	f, err := os.Create(filepath + "/" + stringtool.LowerFirstLetter(name) + ".golem.go")
+	filename := filepath + "/" + stringtool.LowerFirstLetter(name) + ".golem.go"
+	str = consts.HEADER + consts.LN + pkgManager.ToString() + consts.LN + str
+	// go/format does not apply the gofmt -s simplifications, the stringifiers write simplified code
+	formatted, err := format.Source([]byte(str))
 	if err != nil {
-		return merror.Stack(err)
+		return merror.Stack(newFormatError(filename, str, err))
 	}
-	defer f.Close()
 
-	str = consts.HEADER + consts.LN + pkgManager.ToString() + consts.LN + str
-	_, err = f.WriteString(str)
-	if err != nil {
+	if err := output.WriteFile(filename, formatted); err != nil {
 		return merror.Stack(err)
 	}
 
 	return nil
 }
 
-func (g *GenerationUsecaseImpl) generateJavascriptClientUsecase(ctx context.Context, domain *model.Domain, path string) error {
-	// if file path does not exist, create it
-	filepath := path + "/" + domain.Architecture.JavascriptClient
-	if _, err := os.Stat(filepath); os.IsNotExist(err) {
-		if err := os.MkdirAll(filepath, os.ModePerm); err != nil {
-			return merror.Stack(err)
-		}
+// newFormatError locates a formatting error in the generated file, with the offending line
+func newFormatError(filename string, source string, err error) error {
+	var list scanner.ErrorList
+	if !errors.As(err, &list) || len(list) == 0 {
+		return NewErrInvalidGeneratedFile(filename, err.Error())
 	}
 
+	pos := list[0].Pos
+	position := fmt.Sprintf("%s:%d:%d", filename, pos.Line, pos.Column)
+	message := list[0].Msg
+	if lines := strings.Split(source, consts.LN); pos.Line > 0 && pos.Line <= len(lines) {
+		message += consts.LN + consts.TAB + strings.TrimSpace(lines[pos.Line-1])
+	}
+	return NewErrInvalidGeneratedFile(position, message)
+}
+
+func (g *GenerationUsecaseImpl) generateJavascriptClientUsecase(ctx context.Context, output *generationOutput, domain *model.Domain, path string) error {
+	filepath := stringtool.RemoveDuplicate(path+"/"+domain.Architecture.JavascriptClient, '/')
+
 	fileImport := ""
 	export := ""
 
-	for name, content := range domain.JSFiles {
+	// sort files for a deterministic index
+	names := make([]string, 0, len(domain.JSFiles))
+	for name := range domain.JSFiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		content := domain.JSFiles[name]
 		filename := name + ".golem.js"
 		fileImport += fmt.Sprintf("export * from './%s';", filename) + consts.LN
 		export += consts.TAB + fmt.Sprintf("%s,", name) + consts.LN
 
 		// Generate service
-		f, err := os.Create(filepath + "/" + filename)
-		if err != nil {
-			return merror.Stack(err)
-		}
-		defer f.Close()
-
-		_, err = f.WriteString(content)
-		if err != nil {
+		if err := output.WriteFile(filepath+"/"+filename, []byte(content)); err != nil {
 			return merror.Stack(err)
 		}
 	}
 
-	f, err := os.Create(filepath + "/index.js")
-	if err != nil {
-		return merror.Stack(err)
-	}
-	defer f.Close()
-
-	_, err = f.WriteString(fileImport)
-	if err != nil {
+	if err := output.WriteFile(filepath+"/index.js", []byte(fileImport)); err != nil {
 		return merror.Stack(err)
 	}
 
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cleogithub/golem/goGeneration/domain/consts"
+)
+
+// goModContent returns a go.mod of module requiring the given modules, sorted by path.
+// The indirect requirements are written in their own block so that the module builds without go mod tidy.
+func goModContent(module string, requirements map[string]string, indirect map[string]string) []byte {
+	str := fmt.Sprintf("module %s", module) + consts.LN + consts.LN
+	str += fmt.Sprintf("go %s", consts.GO_VERSION) + consts.LN
+
+	str += requireBlock(requirements, "")
+	str += requireBlock(indirect, " // indirect")
+
+	return []byte(str)
+}
+
+func requireBlock(requirements map[string]string, comment string) string {
+	if len(requirements) == 0 {
+		return ""
+	}
+	str := consts.LN + "require (" + consts.LN
+	for _, path := range sortedModules(requirements) {
+		str += consts.TAB + path + " " + requirements[path] + comment + consts.LN
+	}
+	str += ")" + consts.LN
+	return str
+}
+
+// addGoModRequirements appends the requirements missing in an existing go.mod content.
+// Modules already required keep their version so an upgrade done by hand is not reverted.
+func addGoModRequirements(content []byte, requirements map[string]string, indirect map[string]string) []byte {
+	required := map[string]bool{}
+	inBlock := false
+	for _, line := range strings.Split(string(content), consts.LN) {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+		case inBlock && fields[0] == ")":
+			inBlock = false
+		case inBlock:
+			required[fields[0]] = true
+		case fields[0] == "require" && len(fields) > 1 && fields[1] == "(":
+			inBlock = true
+		case fields[0] == "require" && len(fields) > 1:
+			required[fields[1]] = true
+		}
+	}
+
+	str := string(content)
+	add := func(requirements map[string]string, comment string) {
+		for _, path := range sortedModules(requirements) {
+			if required[path] {
+				continue
+			}
+			if !strings.HasSuffix(str, consts.LN) {
+				str += consts.LN
+			}
+			str += fmt.Sprintf("require %s %s", path, requirements[path]) + comment + consts.LN
+		}
+	}
+	add(requirements, "")
+	add(indirect, " // indirect")
+
+	return []byte(str)
+}
+
+// addGoSumLines appends the checksums missing in a go.sum content, an empty content gives a new go.sum
+func addGoSumLines(content []byte, lines []string) []byte {
+	known := map[string]bool{}
+	for _, line := range strings.Split(string(content), consts.LN) {
+		known[strings.TrimSpace(line)] = true
+	}
+
+	str := string(content)
+	for _, line := range lines {
+		if known[line] {
+			continue
+		}
+		if str != "" && !strings.HasSuffix(str, consts.LN) {
+			str += consts.LN
+		}
+		str += line + consts.LN
+	}
+
+	return []byte(str)
+}
+
+func sortedModules(requirements map[string]string) []string {
+	paths := make([]string, 0, len(requirements))
+	for path := range requirements {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"testing"
+)
+
+func TestGoModContent(t *testing.T) {
+	content := goModContent("github.com/acme/shop", map[string]string{"gorm.io/gorm": "v1.25.12"}, map[string]string{"github.com/jinzhu/now": "v1.1.5"})
+	expected := `module github.com/acme/shop
+
+go 1.22
+
+require (
+	gorm.io/gorm v1.25.12
+)
+
+require (
+	github.com/jinzhu/now v1.1.5 // indirect
+)
+`
+	if string(content) != expected {
+		t.Errorf("goModContent() = %q, want %q", content, expected)
+	}
+}
+
+func TestAddGoModRequirements(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "missing requirements are appended",
+			content:  "module shop\n\ngo 1.22\n",
+			expected: "module shop\n\ngo 1.22\nrequire gorm.io/gorm v1.25.12\nrequire github.com/jinzhu/now v1.1.5 // indirect\n",
+		},
+		{
+			name:     "required modules keep their version",
+			content:  "module shop\n\nrequire (\n\tgorm.io/gorm v1.30.0\n)\n\nrequire github.com/jinzhu/now v1.1.4\n",
+			expected: "module shop\n\nrequire (\n\tgorm.io/gorm v1.30.0\n)\n\nrequire github.com/jinzhu/now v1.1.4\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := addGoModRequirements([]byte(tt.content), map[string]string{"gorm.io/gorm": "v1.25.12"}, map[string]string{"github.com/jinzhu/now": "v1.1.5"})
+			if string(content) != tt.expected {
+				t.Errorf("addGoModRequirements() = %q, want %q", content, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAddGoSumLines(t *testing.T) {
+	lines := []string{"gorm.io/gorm v1.25.12 h1:a=", "gorm.io/gorm v1.25.12/go.mod h1:b="}
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "new go.sum",
+			expected: "gorm.io/gorm v1.25.12 h1:a=\ngorm.io/gorm v1.25.12/go.mod h1:b=\n",
+		},
+		{
+			name:     "known checksums are not repeated",
+			content:  "github.com/jinzhu/now v1.1.5 h1:c=\ngorm.io/gorm v1.25.12 h1:a=",
+			expected: "github.com/jinzhu/now v1.1.5 h1:c=\ngorm.io/gorm v1.25.12 h1:a=\ngorm.io/gorm v1.25.12/go.mod h1:b=\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := addGoSumLines([]byte(tt.content), lines)
+			if string(content) != tt.expected {
+				t.Errorf("addGoSumLines() = %q, want %q", content, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cleogithub/golem-common/pkg/merror"
+	"github.com/cleogithub/golem-common/pkg/stringtool"
+	"github.com/cleogithub/golem/coredomaindefinition"
+	"github.com/cleogithub/golem/goGeneration/domain/consts"
+	"github.com/cleogithub/golem/goGeneration/domain/model"
+)
+
+// ROLES_ALL_NAME is the name of the generated list of every platform role
+const ROLES_ALL_NAME = coredomaindefinition.ROLES_ALL_NAME
+
+// GeneratePlatformUsecase implements GenerationUsecase.
+func (g *GenerationUsecaseImpl) GeneratePlatformUsecase(ctx context.Context, platformDefinition coredomaindefinition.Platform, path string) error {
+	if err := platformDefinition.Validate(); err != nil {
+		return merror.Stack(err)
+	}
+
+	for _, domain := range platformDefinition.Domains {
+		if err := g.GenerateDomainUsecase(ctx, *domain, path); err != nil {
+			return merror.Stack(err)
+		}
+	}
+
+	if err := g.generateRolesUsecase(ctx, &platformDefinition, path); err != nil {
+		return merror.Stack(err)
+	}
+
+	if err := g.generateGoWorkUsecase(ctx, &platformDefinition, path); err != nil {
+		return merror.Stack(err)
+	}
+
+	return nil
+}
+
+// generateRolesUsecase writes the shared module declaring a constant for each platform role
+func (g *GenerationUsecaseImpl) generateRolesUsecase(ctx context.Context, platform *coredomaindefinition.Platform, path string) error {
+	folder := stringtool.RemoveDuplicate(path+"/"+consts.ROLES_FOLDER, '/')
+	pkg := &model.GoPkg{
+		ShortName: consts.ROLES_FOLDER,
+		Alias:     consts.ROLES_FOLDER,
+		FullName:  platform.GetPackage() + "/" + consts.ROLES_FOLDER,
+	}
+
+	file := &model.File{
+		Name:     consts.ROLES_FOLDER,
+		Pkg:      pkg,
+		Elements: []interface{}{},
+	}
+	all := &model.Consts{
+		Name:   ROLES_ALL_NAME,
+		Values: []interface{}{},
+	}
+	for _, role := range platform.Roles {
+		file.Elements = append(file.Elements, &model.Var{
+			Name:    GetRoleConstName(ctx, role),
+			Type:    model.PrimitiveTypeString,
+			Value:   role,
+			IsConst: true,
+		})
+		all.Values = append(all.Values, role)
+	}
+	if len(all.Values) > 0 {
+		file.Elements = append(file.Elements, all)
+	}
+
+	output := newGenerationOutput(g.getFileSystem(), folder)
+	if err := g.writeInFolder(ctx, output, folder, pkg, file); err != nil {
+		return merror.Stack(err)
+	}
+
+	if err := output.WriteFile(folder+"/"+consts.GO_MOD_FILE, goModContent(pkg.FullName, nil, nil)); err != nil {
+		return merror.Stack(err)
+	}
+
+	if err := output.Commit(ctx); err != nil {
+		return merror.Stack(err)
+	}
+
+	return nil
+}
+
+// generateGoWorkUsecase writes the go.work using every domain module and the roles module
+func (g *GenerationUsecaseImpl) generateGoWorkUsecase(ctx context.Context, platform *coredomaindefinition.Platform, path string) error {
+	str := fmt.Sprintf("go %s", consts.GO_VERSION) + consts.LN + consts.LN
+	str += "use (" + consts.LN
+	str += consts.TAB + "./" + consts.ROLES_FOLDER + consts.LN
+	for _, domain := range platform.Domains {
+		str += consts.TAB + "./" + domain.Name + consts.LN
+	}
+	str += ")" + consts.LN
+
+	if err := writeIfChanged(g.getFileSystem(), stringtool.RemoveDuplicate(path+"/"+consts.GO_WORK_FILE, '/'), []byte(str)); err != nil {
+		return merror.Stack(err)
+	}
+
+	return nil
+}
+
+// GetRoleConstName returns the go constant name of a role, e.g. "super-admin" gives "SuperAdmin"
+func GetRoleConstName(ctx context.Context, role string) string {
+	return coredomaindefinition.GetRoleConstName(role)
+}